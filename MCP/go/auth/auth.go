@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Identity describes the client that made a request, as established by a
+// verified TLS client certificate.
+type Identity struct {
+	Subject    string // full distinguished name, e.g. "CN=agent,O=Example"
+	CommonName string
+	DNSNames   []string
+	URIs       []string // e.g. SPIFFE IDs
+}
+
+type identityKey struct{}
+
+// FromRequest returns the identity of the verified client certificate on r,
+// if the connection presented one.
+func FromRequest(r *http.Request) (*Identity, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	return fromCertificate(r.TLS.VerifiedChains[0][0]), true
+}
+
+func fromCertificate(cert *x509.Certificate) *Identity {
+	id := &Identity{
+		Subject:    cert.Subject.String(),
+		CommonName: cert.Subject.CommonName,
+		DNSNames:   cert.DNSNames,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+	}
+	return id
+}
+
+// WithIdentity returns a copy of ctx carrying id.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the client identity stored in ctx, if any.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// Allowed reports whether the identity matches one of the glob patterns,
+// compared against the common name, DNS names and URIs. Patterns are matched
+// segment by segment, split on "/": * and ? don't cross a "/", and a "**"
+// segment matches any number of segments, so spiffe://example.org/** matches
+// every SPIFFE ID of the trust domain. An empty pattern list allows every
+// identity.
+func (id *Identity) Allowed(patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	names := append([]string{id.CommonName}, id.DNSNames...)
+	names = append(names, id.URIs...)
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if match(strings.Split(pattern, "/"), strings.Split(name, "/")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func match(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := range len(name) + 1 {
+				if match(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// Middleware refuses requests whose verified client certificate doesn't match
+// the patterns returned by allowed. With no patterns every request passes.
+func Middleware(allowed func() []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if patterns := allowed(); len(patterns) > 0 {
+			if id, ok := FromRequest(r); !ok || !id.Allowed(patterns) {
+				http.Error(w, "Client certificate not allowed", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
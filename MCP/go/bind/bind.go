@@ -0,0 +1,255 @@
+package bind
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/api-video/mcp-server/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Arguments binds the arguments of a tool call to dst, a pointer to a struct
+// whose tags say where each argument goes in the api.video request:
+//
+//	VideoID  string       `path:"videoId"`    // path parameter, always required
+//	Title    string       `query:"title"`     // query string parameter
+//	Public   *bool        `body:"public"`     // field of the JSON request body
+//	Cursor   string       `arg:"cursor"`      // used by the server, not sent
+//	PageSize int          `query:"pageSize" default:"25"`
+//
+// Values are converted to the field types, so numbers bound to int fields
+// must be whole. Fields of embedded structs are bound too. Arguments no
+// field binds are rejected, so nothing unexpected reaches the request.
+func Arguments(request mcp.CallToolRequest, dst any) error {
+	args, ok := request.Params.Arguments.(map[string]any)
+	if request.Params.Arguments == nil {
+		args, ok = map[string]any{}, true
+	}
+	if !ok {
+		return fmt.Errorf("Invalid arguments object")
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		panic("bind: Arguments needs a pointer to a struct")
+	}
+	bound := map[string]bool{}
+	if err := bindStruct(v.Elem(), args, bound); err != nil {
+		return err
+	}
+
+	var unknown []string
+	for name := range args {
+		if !bound[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return fmt.Errorf("Unknown arguments: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// field is a struct field bound to an argument.
+type field struct {
+	in   string // path, query, body or arg
+	name string
+}
+
+// fieldOf returns where a struct field is bound, if it is.
+func fieldOf(sf reflect.StructField) (field, bool) {
+	for _, in := range []string{"path", "query", "body", "arg"} {
+		if name, ok := sf.Tag.Lookup(in); ok {
+			return field{in: in, name: name}, true
+		}
+	}
+	return field{}, false
+}
+
+func bindStruct(v reflect.Value, args map[string]any, bound map[string]bool) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			if err := bindStruct(v.Field(i), args, bound); err != nil {
+				return err
+			}
+			continue
+		}
+		f, ok := fieldOf(sf)
+		if !ok {
+			continue
+		}
+		bound[f.name] = true
+
+		val, ok := args[f.name]
+		if !ok || val == nil {
+			def, hasDefault := sf.Tag.Lookup("default")
+			switch {
+			case hasDefault:
+				if err := setDefault(v.Field(i), def); err != nil {
+					panic(fmt.Sprintf("bind: bad default for %s: %v", f.name, err))
+				}
+			case f.in == "path":
+				return fmt.Errorf("Missing required path parameter: %s", f.name)
+			}
+			continue
+		}
+		if f.in == "path" {
+			if s, ok := val.(string); !ok || s == "" {
+				return fmt.Errorf("Invalid path parameter: %s", f.name)
+			}
+		}
+		// Convert through JSON, the form the arguments arrived in
+		raw, err := json.Marshal(val)
+		if err == nil {
+			err = json.Unmarshal(raw, v.Field(i).Addr().Interface())
+		}
+		if err != nil {
+			return fmt.Errorf("Invalid argument %s: expected %s", f.name, describe(sf.Type))
+		}
+	}
+	return nil
+}
+
+func setDefault(v reflect.Value, def string) error {
+	if v.Kind() == reflect.String {
+		v.SetString(def)
+		return nil
+	}
+	return json.Unmarshal([]byte(def), v.Addr().Interface())
+}
+
+// describe names a field type in error messages.
+func describe(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return "a whole number"
+	case reflect.Float64, reflect.Float32:
+		return "a number"
+	case reflect.Slice:
+		return "an array of " + strings.TrimPrefix(strings.TrimPrefix(describe(t.Elem()), "a "), "an ") + "s"
+	}
+	return "an object"
+}
+
+// fields calls fn with every bound field of src, a struct or a pointer to
+// one, in declaration order.
+func fields(src any, fn func(f field, v reflect.Value)) {
+	v := reflect.ValueOf(src)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		t := v.Type()
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				walk(v.Field(i))
+				continue
+			}
+			if f, ok := fieldOf(sf); ok {
+				fn(f, v.Field(i))
+			}
+		}
+	}
+	walk(v)
+}
+
+// URL returns base with the {name} placeholders of the path replaced by the
+// escaped path parameters of src, followed by its query parameters. Query
+// parameters with zero values are left out.
+func URL(base string, src any) string {
+	query := url.Values{}
+	fields(src, func(f field, v reflect.Value) {
+		switch f.in {
+		case "path":
+			base = strings.ReplaceAll(base, "{"+f.name+"}", url.PathEscape(v.String()))
+		case "query":
+			if !v.IsZero() {
+				addQuery(query, f.name, v)
+			}
+		}
+	})
+	if len(query) == 0 {
+		return base
+	}
+	return base + "?" + query.Encode()
+}
+
+// QueryEncoder is implemented by argument types with their own query string
+// form.
+type QueryEncoder interface {
+	EncodeQuery(name string, query url.Values)
+}
+
+func addQuery(query url.Values, name string, v reflect.Value) {
+	if enc, ok := v.Interface().(QueryEncoder); ok {
+		enc.EncodeQuery(name, query)
+		return
+	}
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		// api.video takes lists as repeated name[] parameters
+		for i := range v.Len() {
+			query.Add(name+"[]", scalar(v.Index(i)))
+		}
+	default:
+		query.Set(name, scalar(v))
+	}
+}
+
+func scalar(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Float64, reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Body returns the JSON request body made of the body fields of src. Nil
+// pointers, slices and maps are left out, so optional arguments the caller
+// didn't pass aren't sent.
+func Body(src any) ([]byte, error) {
+	body := map[string]any{}
+	fields(src, func(f field, v reflect.Value) {
+		if f.in != "body" {
+			return
+		}
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			if v.IsNil() {
+				return
+			}
+		}
+		body[f.name] = v.Interface()
+	})
+	return json.Marshal(body)
+}
+
+// Metadata is a list of key/value pairs. In a query string it is sent as
+// metadata[key]=value.
+type Metadata []models.Metadata
+
+func (m Metadata) EncodeQuery(name string, query url.Values) {
+	for _, pair := range m {
+		query.Add(fmt.Sprintf("%s[%s]", name, pair.Key), pair.Value)
+	}
+}
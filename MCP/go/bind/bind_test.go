@@ -0,0 +1,135 @@
+package bind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Page is embedded to check that the fields of embedded structs are bound.
+type Page struct {
+	CurrentPage int `query:"currentPage" default:"1"`
+	PageSize    int `query:"pageSize" default:"25"`
+}
+
+type testArgs struct {
+	VideoID  string   `path:"videoId"`
+	Title    string   `query:"title"`
+	Tags     []string `query:"tags"`
+	Metadata Metadata `query:"metadata"`
+	Page
+	Public    *bool    `body:"public"`
+	Name      *string  `body:"name"`
+	Ratio     float64  `body:"ratio"`
+	Chapters  []string `body:"chapters"`
+	Delivery  string   `arg:"delivery" default:"notification"`
+	Timeout   int      `arg:"timeout" default:"300"`
+	Untouched string
+}
+
+func bindArgs(arguments any) (testArgs, error) {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = arguments
+	var args testArgs
+	err := Arguments(request, &args)
+	return args, err
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestArguments(t *testing.T) {
+	defaults := testArgs{VideoID: "vi1", Page: Page{CurrentPage: 1, PageSize: 25}, Delivery: "notification", Timeout: 300}
+	with := func(edit func(*testArgs)) testArgs {
+		args := defaults
+		edit(&args)
+		return args
+	}
+	tests := []struct {
+		name    string
+		args    any
+		want    testArgs
+		wantErr string
+	}{
+		{"defaults", map[string]any{"videoId": "vi1"}, defaults, ""},
+		{"null takes the default", map[string]any{"videoId": "vi1", "pageSize": nil, "delivery": nil}, defaults, ""},
+		{"values", map[string]any{
+			"videoId": "vi1", "title": "t", "tags": []any{"a", "b"}, "metadata": []any{map[string]any{"key": "k", "value": "v"}},
+			"currentPage": 2.0, "pageSize": 100.0, "ratio": 0.5, "delivery": "resourceUpdated", "timeout": 10.0,
+		}, with(func(a *testArgs) {
+			a.Title, a.Tags, a.Metadata = "t", []string{"a", "b"}, Metadata{{Key: "k", Value: "v"}}
+			a.Page, a.Ratio, a.Delivery, a.Timeout = Page{CurrentPage: 2, PageSize: 100}, 0.5, "resourceUpdated", 10
+		}), ""},
+		{"pointers set to zero values", map[string]any{"videoId": "vi1", "public": false, "name": ""}, with(func(a *testArgs) {
+			a.Public, a.Name = ptr(false), ptr("")
+		}), ""},
+		{"pointers left nil", map[string]any{"videoId": "vi1", "public": nil}, defaults, ""},
+		{"empty list", map[string]any{"videoId": "vi1", "chapters": []any{}}, with(func(a *testArgs) { a.Chapters = []string{} }), ""},
+		{"no arguments", nil, testArgs{}, "Missing required path parameter: videoId"},
+		{"missing path", map[string]any{"title": "t"}, testArgs{}, "Missing required path parameter: videoId"},
+		{"empty path", map[string]any{"videoId": ""}, testArgs{}, "Invalid path parameter: videoId"},
+		{"path not a string", map[string]any{"videoId": 1.0}, testArgs{}, "Invalid path parameter: videoId"},
+		{"not an object", []any{"vi1"}, testArgs{}, "Invalid arguments object"},
+		{"unknown", map[string]any{"videoId": "vi1", "zz": 1.0, "Untouched": "x"}, testArgs{}, "Unknown arguments: Untouched, zz"},
+		{"fraction for int", map[string]any{"videoId": "vi1", "timeout": 1.5}, testArgs{}, "Invalid argument timeout: expected a whole number"},
+		{"wrong type", map[string]any{"videoId": "vi1", "public": "yes"}, testArgs{}, "Invalid argument public: expected true or false"},
+		{"wrong item type", map[string]any{"videoId": "vi1", "tags": []any{1.0}}, testArgs{}, "Invalid argument tags: expected an array of strings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bindArgs(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Arguments error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Arguments = %+v, %v, want %+v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		name string
+		args testArgs
+		want string
+	}{
+		{"path only", testArgs{VideoID: "vi1"}, "https://api/videos/vi1/captions"},
+		{"path escaped", testArgs{VideoID: "a/../b"}, "https://api/videos/a%2F..%2Fb/captions"},
+		{"query", testArgs{VideoID: "vi1", Title: "a b", Page: Page{CurrentPage: 2}}, "https://api/videos/vi1/captions?currentPage=2&title=a+b"},
+		{"lists", testArgs{VideoID: "vi1", Tags: []string{"x", "y"}}, "https://api/videos/vi1/captions?tags%5B%5D=x&tags%5B%5D=y"},
+		{"metadata", testArgs{VideoID: "vi1", Metadata: Metadata{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}}, "https://api/videos/vi1/captions?metadata%5Ba%5D=1&metadata%5Bb%5D=2"},
+		{"body and arg fields left out", testArgs{VideoID: "vi1", Public: ptr(true), Delivery: "x"}, "https://api/videos/vi1/captions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := URL("https://api/videos/{videoId}/captions", tt.args); got != tt.want {
+				t.Errorf("URL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBody(t *testing.T) {
+	tests := []struct {
+		name string
+		args testArgs
+		want string
+	}{
+		{"optional fields left out", testArgs{VideoID: "vi1", Title: "t"}, `{"ratio":0}`},
+		{"explicit false and empty", testArgs{Public: ptr(false), Name: ptr("")}, `{"name":"","public":false,"ratio":0}`},
+		{"empty list sent", testArgs{Chapters: []string{}}, `{"chapters":[],"ratio":0}`},
+		{"values", testArgs{Public: ptr(true), Ratio: 1.5, Chapters: []string{"c"}}, `{"chapters":["c"],"public":true,"ratio":1.5}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Body(tt.args)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("Body = %s, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+}
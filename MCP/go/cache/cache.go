@@ -0,0 +1,275 @@
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+)
+
+// entry is a cached response.
+type entry struct {
+	Key        string      `json:"key"` // hash of the credentials, then the URL
+	Path       string      `json:"path"`
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	Stored     time.Time   `json:"stored"`
+	Expires    time.Time   `json:"expires"`
+}
+
+// Transport answers GET requests from the cache while their entry is fresh,
+// revalidates stale entries that have an ETag, and sends everything else
+// through Next. Any other method drops the entries of the path it changes
+// and of the paths above and below it, whatever the credentials.
+type Transport struct {
+	Next http.RoundTripper
+
+	cfg     config.CacheConfig
+	mu      sync.Mutex
+	entries map[string]*list.Element // key → element of order holding the entry
+	order   *list.List               // entries, least recently stored first
+
+	disk sync.Mutex // serializes writes to cfg.Dir, which happen without mu held
+}
+
+// New returns a Transport caching the responses of next as cfg says, with
+// the entries stored in cfg.Dir loaded.
+func New(cfg config.CacheConfig, next http.RoundTripper) *Transport {
+	t := &Transport{Next: next, cfg: cfg, entries: map[string]*list.Element{}, order: list.New()}
+	if cfg.Dir != "" {
+		t.load()
+	}
+	return t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.invalidate(req.URL.Path)
+		resp, err := t.Next.RoundTrip(req)
+		// A read made while the change was in flight may have stored the old state
+		t.invalidate(req.URL.Path)
+		return resp, err
+	}
+	ttl := t.ttl(req.URL.Path)
+	if ttl <= 0 {
+		return t.Next.RoundTrip(req)
+	}
+
+	op := metrics.Operation(req.Context())
+	key := cacheKey(req)
+	cached := t.lookup(key)
+	if cached != nil && time.Now().Before(cached.Expires) {
+		metrics.CacheLookups.WithLabelValues(op, "hit").Inc()
+		return cached.response(req), nil
+	}
+
+	if etag := cached.etag(); etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		metrics.CacheLookups.WithLabelValues(op, "revalidated").Inc()
+		refreshed := *cached
+		refreshed.Expires = time.Now().Add(ttl)
+		t.store(&refreshed)
+		return refreshed.response(req), nil
+	}
+	metrics.CacheLookups.WithLabelValues(op, "miss").Inc()
+	if resp.StatusCode != http.StatusOK || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	now := time.Now()
+	t.store(&entry{
+		Key:        key,
+		Path:       req.URL.Path,
+		StatusCode: resp.StatusCode,
+		Header:     http.Header{"Content-Type": resp.Header.Values("Content-Type"), "Etag": resp.Header.Values("Etag")},
+		Body:       body,
+		Stored:     now,
+		Expires:    now.Add(ttl),
+	})
+	return resp, nil
+}
+
+// ttl returns how long responses for urlPath stay fresh, 0 if they aren't
+// cached. The longest matching pattern wins.
+func (t *Transport) ttl(urlPath string) time.Duration {
+	best, ttl := "", time.Duration(0)
+	for pattern, d := range t.cfg.TTLs {
+		if ok, _ := path.Match(pattern, urlPath); ok && len(pattern) > len(best) {
+			best, ttl = pattern, d
+		}
+	}
+	return ttl
+}
+
+// cacheKey returns the key of a request, made of its credentials, URL and
+// query. Credentials are hashed so they are never stored.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:]) + " " + req.URL.String()
+}
+
+func (e *entry) etag() string {
+	if e == nil {
+		return ""
+	}
+	return e.Header.Get("Etag")
+}
+
+func (e *entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// lookup returns the entry of key, or nil.
+func (t *Transport) lookup(key string) *entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if el, ok := t.entries[key]; ok {
+		return el.Value.(*entry)
+	}
+	return nil
+}
+
+// store adds an entry, evicting the least recently stored ones beyond
+// MaxEntries.
+func (t *Transport) store(e *entry) {
+	t.mu.Lock()
+	changed := []string{e.Key}
+	if el, ok := t.entries[e.Key]; ok {
+		t.order.Remove(el)
+	}
+	t.entries[e.Key] = t.order.PushBack(e)
+	for len(t.entries) > max(t.cfg.MaxEntries, 1) {
+		oldest := t.order.Remove(t.order.Front()).(*entry)
+		delete(t.entries, oldest.Key)
+		changed = append(changed, oldest.Key)
+	}
+	t.mu.Unlock()
+	t.persist(changed)
+}
+
+// invalidate drops the entries of urlPath and of the paths above and below
+// it: a change to a video also changes its captions list, and a change to a
+// caption also changes the video.
+func (t *Transport) invalidate(urlPath string) {
+	t.mu.Lock()
+	var changed []string
+	for key, el := range t.entries {
+		e := el.Value.(*entry)
+		if e.Path == urlPath || strings.HasPrefix(urlPath, e.Path+"/") || strings.HasPrefix(e.Path, urlPath+"/") {
+			t.order.Remove(el)
+			delete(t.entries, key)
+			changed = append(changed, key)
+		}
+	}
+	t.mu.Unlock()
+	t.persist(changed)
+}
+
+// persist brings the files of keys in line with the entries in memory,
+// writing the entries still cached and removing the others. It runs without
+// t.mu held, so lookups don't wait for the disk, and checks each entry when
+// writing so a file never outlives an entry dropped meanwhile.
+func (t *Transport) persist(keys []string) {
+	if t.cfg.Dir == "" || len(keys) == 0 {
+		return
+	}
+	t.disk.Lock()
+	defer t.disk.Unlock()
+	for _, key := range keys {
+		if e := t.lookup(key); e != nil {
+			t.save(e)
+		} else if err := os.Remove(t.file(key)); err != nil && !os.IsNotExist(err) {
+			slog.Warn("Failed to remove cache entry", "error", err)
+		}
+	}
+}
+
+func (t *Transport) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(t.cfg.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// save writes an entry to disk. Entries hold API responses, so the files are
+// only readable by the server user. t.disk must be held.
+func (t *Transport) save(e *entry) {
+	data, err := json.Marshal(e)
+	if err == nil {
+		err = os.MkdirAll(t.cfg.Dir, 0o700)
+	}
+	if err == nil {
+		tmp := t.file(e.Key) + ".tmp"
+		if err = os.WriteFile(tmp, data, 0o600); err == nil {
+			err = os.Rename(tmp, t.file(e.Key))
+		}
+	}
+	if err != nil {
+		slog.Warn("Failed to write cache entry", "error", err)
+	}
+}
+
+// load reads the entries stored in the cache directory. Expired entries are
+// kept if they can be revalidated.
+func (t *Transport) load() {
+	files, err := filepath.Glob(filepath.Join(t.cfg.Dir, "*.json"))
+	if err != nil {
+		return
+	}
+	var loaded []*entry
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		var e entry
+		if err == nil {
+			err = json.Unmarshal(data, &e)
+		}
+		if err != nil || e.Key == "" || t.file(e.Key) != name || (e.etag() == "" && time.Now().After(e.Expires)) {
+			os.Remove(name)
+			continue
+		}
+		loaded = append(loaded, &e)
+	}
+	slices.SortFunc(loaded, func(a, b *entry) int { return a.Stored.Compare(b.Stored) })
+	for _, e := range loaded {
+		t.entries[e.Key] = t.order.PushBack(e)
+	}
+	slog.Debug("Loaded response cache", "dir", t.cfg.Dir, "entries", len(t.entries))
+}
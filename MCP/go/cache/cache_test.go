@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+)
+
+func TestTTL(t *testing.T) {
+	tr := New(config.CacheConfig{TTLs: map[string]time.Duration{
+		"/account":         time.Minute,
+		"/videos/*":        30 * time.Second,
+		"/videos/*/status": 0,
+		"/videos/*/*":      10 * time.Second,
+	}}, nil)
+	tests := []struct {
+		path string
+		want time.Duration
+	}{
+		{"/account", time.Minute},
+		{"/account/other", 0},
+		{"/videos/vi1", 30 * time.Second},
+		{"/videos/vi1/captions", 10 * time.Second},
+		{"/videos/vi1/status", 0}, // the longest pattern wins, even when it turns caching off
+		{"/videos", 0},
+		{"/players/pl1", 0},
+	}
+	for _, tt := range tests {
+		if got := tr.ttl(tt.path); got != tt.want {
+			t.Errorf("ttl(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// upstream counts the requests it serves and answers with an ETag and a body
+// naming the request number, or with 304 to conditional requests when
+// notModified is set.
+type upstream struct {
+	requests    atomic.Int32
+	conditional atomic.Int32
+	notModified bool
+	cacheHeader string
+}
+
+func (u *upstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := u.requests.Add(1)
+	if r.Header.Get("If-None-Match") != "" {
+		u.conditional.Add(1)
+		if u.notModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Etag", `"v1"`)
+	if u.cacheHeader != "" {
+		w.Header().Set("Cache-Control", u.cacheHeader)
+	}
+	fmt.Fprintf(w, `{"response":%d}`, n)
+}
+
+func newTestTransport(t *testing.T, u *upstream, cfg config.CacheConfig) (*Transport, string) {
+	t.Helper()
+	srv := httptest.NewServer(u)
+	t.Cleanup(srv.Close)
+	if cfg.TTLs == nil {
+		cfg.TTLs = map[string]time.Duration{"/videos/*": time.Hour, "/videos/*/*": time.Hour, "/videos": time.Hour}
+	}
+	if cfg.MaxEntries == 0 {
+		cfg.MaxEntries = 100
+	}
+	return New(cfg, http.DefaultTransport), srv.URL
+}
+
+// get sends a GET with the credentials in auth and returns the body.
+func get(t *testing.T, tr *Transport, url, auth string) string {
+	t.Helper()
+	return send(t, tr, http.MethodGet, url, auth)
+}
+
+func send(t *testing.T, tr *Transport, method, url, auth string) string {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, nil)
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestLookups(t *testing.T) {
+	tests := []struct {
+		name         string
+		cacheHeader  string
+		first, again string // paths of the two requests
+		auth         string // credentials of the second request
+		wantRequests int32
+	}{
+		{"hit", "", "/videos/vi1", "/videos/vi1", "a", 1},
+		{"other path", "", "/videos/vi1", "/videos/vi2", "a", 2},
+		{"other query", "", "/videos/vi1", "/videos/vi1?x=1", "a", 2},
+		{"other credentials", "", "/videos/vi1", "/videos/vi1", "b", 2},
+		{"uncached path", "", "/players/pl1", "/players/pl1", "a", 2},
+		{"no-store", "no-store", "/videos/vi1", "/videos/vi1", "a", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &upstream{cacheHeader: tt.cacheHeader}
+			tr, url := newTestTransport(t, u, config.CacheConfig{})
+			get(t, tr, url+tt.first, "a")
+			get(t, tr, url+tt.again, tt.auth)
+			if got := u.requests.Load(); got != tt.wantRequests {
+				t.Errorf("upstream served %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRevalidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		notModified bool
+		want        string
+	}{
+		{"not modified", true, `{"response":1}`},
+		{"modified", false, `{"response":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &upstream{notModified: tt.notModified}
+			tr, url := newTestTransport(t, u, config.CacheConfig{TTLs: map[string]time.Duration{"/videos/*": time.Millisecond}})
+			get(t, tr, url+"/videos/vi1", "a")
+			time.Sleep(5 * time.Millisecond)
+			if got := get(t, tr, url+"/videos/vi1", "a"); got != tt.want {
+				t.Errorf("body after expiry = %s, want %s", got, tt.want)
+			}
+			if got := u.conditional.Load(); got != 1 {
+				t.Errorf("upstream got %d conditional requests, want 1", got)
+			}
+		})
+	}
+}
+
+func TestInvalidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  string // path of the PATCH
+		dropped []string
+		kept    []string
+	}{
+		{"same path", "/videos/vi1", []string{"/videos", "/videos/vi1", "/videos/vi1/captions"}, []string{"/videos/vi2"}},
+		{"path below", "/videos/vi1/captions", []string{"/videos/vi1", "/videos/vi1/captions", "/videos"}, []string{"/videos/vi2"}},
+		{"collection", "/videos", []string{"/videos", "/videos/vi1", "/videos/vi2"}, nil},
+		{"unrelated", "/players/pl1", nil, []string{"/videos", "/videos/vi1", "/videos/vi2"}},
+	}
+	paths := []string{"/videos", "/videos/vi1", "/videos/vi1/captions", "/videos/vi2"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &upstream{}
+			tr, url := newTestTransport(t, u, config.CacheConfig{})
+			cached := map[string]string{}
+			for _, path := range paths {
+				cached[path] = get(t, tr, url+path, "a")
+			}
+			// Changes drop entries whatever the credentials
+			send(t, tr, http.MethodPatch, url+tt.change, "b")
+			for _, path := range tt.dropped {
+				if get(t, tr, url+path, "a") == cached[path] {
+					t.Errorf("%s still cached after a change to %s", path, tt.change)
+				}
+			}
+			for _, path := range tt.kept {
+				if get(t, tr, url+path, "a") != cached[path] {
+					t.Errorf("%s dropped after a change to %s", path, tt.change)
+				}
+			}
+		})
+	}
+}
+
+func TestEviction(t *testing.T) {
+	u := &upstream{}
+	tr, url := newTestTransport(t, u, config.CacheConfig{MaxEntries: 2})
+	first := get(t, tr, url+"/videos/vi1", "a")
+	get(t, tr, url+"/videos/vi2", "a")
+	get(t, tr, url+"/videos/vi3", "a")
+	if len(tr.entries) != 2 || tr.order.Len() != 2 {
+		t.Fatalf("cache holds %d entries and %d in order, want 2", len(tr.entries), tr.order.Len())
+	}
+	if get(t, tr, url+"/videos/vi1", "a") == first {
+		t.Error("the least recently stored entry was kept")
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	u := &upstream{}
+	tr, url := newTestTransport(t, u, config.CacheConfig{Dir: dir})
+	kept := get(t, tr, url+"/videos/vi1", "a")
+	get(t, tr, url+"/videos/vi2", "a")
+	send(t, tr, http.MethodDelete, url+"/videos/vi2", "a")
+
+	// A new transport loads the entries left on disk
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Errorf("cache dir holds %d entries, want 1", len(files))
+	}
+	reloaded := New(tr.cfg, http.DefaultTransport)
+	if got := get(t, reloaded, url+"/videos/vi1", "a"); got != kept {
+		t.Errorf("body after reload = %s, want %s", got, kept)
+	}
+	if got := u.requests.Load(); got != 3 {
+		t.Errorf("upstream served %d requests, want 3", got)
+	}
+}
@@ -0,0 +1,11 @@
+package completion
+
+// bcp47 lists common BCP 47 language tags, offered when completing caption
+// and chapter languages.
+var bcp47 = []string{
+	"ar", "bg", "ca", "cs", "da", "de", "el", "en", "en-AU", "en-GB", "en-US",
+	"es", "es-419", "es-ES", "et", "fa", "fi", "fr", "fr-CA", "fr-FR", "he",
+	"hi", "hr", "hu", "id", "it", "ja", "ko", "lt", "lv", "ms", "nb", "nl",
+	"pl", "pt", "pt-BR", "pt-PT", "ro", "ru", "sk", "sl", "sr", "sv", "th",
+	"tl", "tr", "uk", "vi", "zh", "zh-Hans", "zh-Hant",
+}
@@ -0,0 +1,266 @@
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/rpc"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxValues is the most values one completion may return.
+const maxValues = 100
+
+// lookupTimeout bounds the api.video requests made for one completion, so a
+// slow API doesn't stall typing in the client.
+const lookupTimeout = 3 * time.Second
+
+// completer returns the candidate values for an argument. Values already
+// known for other arguments are in args.
+type completer func(ctx context.Context, cfg *config.APIConfig, value string, args map[string]string) ([]string, error)
+
+// completers are keyed by argument name: the same names are used by the
+// prompts, the resource templates and the tools.
+var completers = map[string]completer{
+	"videoId":      videoIDs,
+	"liveStreamId": liveStreamIDs,
+	"playerId":     playerIDs,
+	"webhookId":    webhookIDs,
+	"language":     languages,
+	"sortBy":       enum("publishedAt", "title", "createdAt", "updatedAt", "name"),
+	"sortOrder":    enum("asc", "desc"),
+	"record":       enum("true", "false"),
+	"period":       periods,
+}
+
+// Register serves completion/complete on router and advertises the
+// completions capability.
+func Register(router *rpc.Router) {
+	router.Capability("completions", struct{}{})
+	router.Handle("completion/complete", complete)
+}
+
+func complete(ctx context.Context, call rpc.Call) (any, error) {
+	var params struct {
+		Ref struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+			URI  string `json:"uri"`
+		} `json:"ref"`
+		Argument struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"argument"`
+		Context struct {
+			Arguments map[string]string `json:"arguments"`
+		} `json:"context"`
+	}
+	if err := json.Unmarshal(call.Params, &params); err != nil {
+		return nil, rpc.InvalidParams(err)
+	}
+	if params.Ref.Type != "ref/prompt" && params.Ref.Type != "ref/resource" {
+		return nil, rpc.InvalidParams(fmt.Errorf("unsupported ref type %q", params.Ref.Type))
+	}
+
+	var result mcp.CompleteResult
+	result.Completion.Values = []string{}
+	complete, ok := completers[params.Argument.Name]
+	if !ok {
+		return result, nil
+	}
+	ctx, cancel := context.WithTimeout(metrics.WithOperation(ctx, "completion"), lookupTimeout)
+	defer cancel()
+	values, err := complete(ctx, call.Config(), params.Argument.Value, params.Context.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) > maxValues {
+		result.Completion.Total = len(values)
+		result.Completion.HasMore = true
+		values = values[:maxValues]
+	}
+	result.Completion.Values = append(result.Completion.Values, values...)
+	return result, nil
+}
+
+// enum completes from a fixed list of values.
+func enum(values ...string) completer {
+	return func(_ context.Context, _ *config.APIConfig, value string, _ map[string]string) ([]string, error) {
+		return withPrefix(values, value), nil
+	}
+}
+
+// withPrefix returns the values starting with prefix, ignoring case.
+func withPrefix(values []string, prefix string) []string {
+	var matches []string
+	for _, val := range values {
+		if strings.HasPrefix(strings.ToLower(val), strings.ToLower(prefix)) {
+			matches = append(matches, val)
+		}
+	}
+	return matches
+}
+
+// videoIDs completes video IDs from the videos whose title contains value,
+// or the most recent videos while nothing is typed.
+func videoIDs(ctx context.Context, cfg *config.APIConfig, value string, _ map[string]string) ([]string, error) {
+	query := url.Values{"pageSize": {"25"}}
+	if value == "" {
+		query.Set("sortBy", "publishedAt")
+		query.Set("sortOrder", "desc")
+	} else {
+		query.Set("title", value)
+	}
+	var list struct {
+		Data []struct {
+			VideoID string `json:"videoId"`
+		} `json:"data"`
+	}
+	if err := get(ctx, cfg, "/videos", query, &list); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, video := range list.Data {
+		ids = append(ids, video.VideoID)
+	}
+	// The value may also be the start of an ID
+	if strings.HasPrefix(value, "vi") {
+		ids = append(ids, value)
+	}
+	return compact(ids), nil
+}
+
+// liveStreamIDs completes live stream IDs from the streams whose name
+// contains value.
+func liveStreamIDs(ctx context.Context, cfg *config.APIConfig, value string, _ map[string]string) ([]string, error) {
+	query := url.Values{"pageSize": {"25"}}
+	if value != "" {
+		query.Set("name", value)
+	}
+	var list struct {
+		Data []struct {
+			LiveStreamID string `json:"liveStreamId"`
+		} `json:"data"`
+	}
+	if err := get(ctx, cfg, "/live-streams", query, &list); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, stream := range list.Data {
+		ids = append(ids, stream.LiveStreamID)
+	}
+	return compact(ids), nil
+}
+
+// playerIDs completes player IDs starting with value. Players have no name
+// to search on.
+func playerIDs(ctx context.Context, cfg *config.APIConfig, value string, _ map[string]string) ([]string, error) {
+	var list struct {
+		Data []struct {
+			PlayerID string `json:"playerId"`
+		} `json:"data"`
+	}
+	if err := get(ctx, cfg, "/players", url.Values{"pageSize": {"100"}}, &list); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, player := range list.Data {
+		ids = append(ids, player.PlayerID)
+	}
+	return withPrefix(ids, value), nil
+}
+
+// webhookIDs completes webhook IDs starting with value.
+func webhookIDs(ctx context.Context, cfg *config.APIConfig, value string, _ map[string]string) ([]string, error) {
+	var list struct {
+		Data []struct {
+			WebhookID string `json:"webhookId"`
+		} `json:"data"`
+	}
+	if err := get(ctx, cfg, "/webhooks", url.Values{"pageSize": {"100"}}, &list); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, webhook := range list.Data {
+		ids = append(ids, webhook.WebhookID)
+	}
+	return withPrefix(ids, value), nil
+}
+
+// languages completes BCP 47 language tags, starting with the captions the
+// video already has when its videoId is known.
+func languages(ctx context.Context, cfg *config.APIConfig, value string, args map[string]string) ([]string, error) {
+	var existing []string
+	if videoID := args["videoId"]; videoID != "" {
+		var list struct {
+			Data []struct {
+				Srclang string `json:"srclang"`
+			} `json:"data"`
+		}
+		// Fall back to the common tags if the video can't be read
+		if err := get(ctx, cfg, "/videos/"+url.PathEscape(videoID)+"/captions", url.Values{"pageSize": {"100"}}, &list); err == nil {
+			for _, caption := range list.Data {
+				existing = append(existing, caption.Srclang)
+			}
+		}
+	}
+	return compact(withPrefix(append(existing, bcp47...), value)), nil
+}
+
+// periods completes analytics periods with the current day, week, month and
+// year.
+func periods(_ context.Context, _ *config.APIConfig, value string, _ map[string]string) ([]string, error) {
+	now := time.Now().UTC()
+	year, week := now.ISOWeek()
+	return withPrefix([]string{
+		now.Format("2006-01-02"),
+		fmt.Sprintf("%d-W%02d", year, week),
+		now.Format("2006-01"),
+		now.Format("2006"),
+	}, value), nil
+}
+
+// compact drops empty and duplicate values, keeping the first occurrence.
+func compact(values []string) []string {
+	seen := map[string]bool{}
+	return slices.DeleteFunc(values, func(val string) bool {
+		drop := val == "" || seen[val]
+		seen[val] = true
+		return drop
+	})
+}
+
+// get calls a list endpoint of the api.video API and decodes the response.
+func get(ctx context.Context, cfg *config.APIConfig, path string, query url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s?%s", cfg.BaseURL, path, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	if cfg.BearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cfg.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("API error: %s", body)
+	}
+	return json.Unmarshal(body, out)
+}
@@ -1,48 +1,573 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+// APIConfig holds everything a tool handler needs to call the api.video API.
 type APIConfig struct {
 	BaseURL     string
 	BearerToken string // For OAuth2/Bearer authentication
 	APIKey      string // For API key authentication
 	BasicAuth   string // For basic authentication
-	Port        string // For server port configuration
+	Client      *http.Client
+}
+
+// HTTPClient returns the client tool handlers should use for outbound requests.
+func (c *APIConfig) HTTPClient() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
+
+// Config is the schema of the server configuration file. Every field can also
+// be set through an environment variable, which takes precedence over the file.
+type Config struct {
+	Transport string                    `yaml:"transport"` // stdio, http or https
+	Port      string                    `yaml:"port"`
+	TLS       TLSConfig                 `yaml:"tls"`
+	API       APISection                `yaml:"api"`
+	Profile   string                    `yaml:"profile"` // profile used in STDIO mode
+	Profiles  map[string]ProfileSection `yaml:"profiles"`
+	HTTP      HTTPConfig                `yaml:"http"`
+	Tools     ToolsConfig               `yaml:"tools"`
+	Reload    ReloadConfig              `yaml:"reload"`
+	Metrics   MetricsConfig             `yaml:"metrics"`
+	Tracing   TracingConfig             `yaml:"tracing"`
+	Logging   LoggingConfig             `yaml:"logging"`
+	Health    HealthConfig              `yaml:"health"`
+	Shutdown  ShutdownConfig            `yaml:"shutdown"`
+	Resources ResourcesConfig           `yaml:"resources"`
+	Webhooks  WebhooksConfig            `yaml:"webhooks"`
+}
+
+// TLSConfig holds the certificate and TLS policy used in HTTPS mode.
+type TLSConfig struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+
+	// ClientCAFiles are PEM bundles used to verify client certificates.
+	// ClientAuth is one of none, request, require, verify-if-given or
+	// require-and-verify; it defaults to require-and-verify when client CAs
+	// are set. AllowedClients are glob patterns matched against the verified
+	// certificate's common name, DNS names and URIs (see auth.Identity.Allowed);
+	// empty allows any. They gate /mcp, /readyz and metrics on the listener.
+	ClientCAFiles  []string `yaml:"clientCAFiles"`
+	ClientAuth     string   `yaml:"clientAuth"`
+	AllowedClients []string `yaml:"allowedClients"`
+
+	MinVersion   string   `yaml:"minVersion"`   // 1.2 (default) or 1.3
+	CipherSuites []string `yaml:"cipherSuites"` // Go names, e.g. TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256; TLS 1.2 only
+	NextProtos   []string `yaml:"nextProtos"`   // ALPN, default h2 and http/1.1
+}
+
+// APISection holds the api.video endpoint and credentials used in STDIO mode.
+// In HTTP/HTTPS mode credentials come from the request headers and BaseURL is
+// only a fallback for requests that don't send API_BASE_URL. Named profiles use
+// the same fields; empty profile fields fall back to the api section.
+type APISection struct {
+	BaseURL     string `yaml:"baseURL"`
+	BearerToken string `yaml:"bearerToken"`
+	APIKey      string `yaml:"apiKey"`
+	BasicAuth   string `yaml:"basicAuth"`
+}
+
+// ProfileSection is a named credential profile. In HTTPS mode a client can
+// select it with the API_PROFILE header only if its verified certificate
+// matches one of AllowedClients, glob patterns like tls.allowedClients;
+// without them the profile is only used in STDIO mode and for webhooks.
+type ProfileSection struct {
+	APISection     `yaml:",inline"`
+	AllowedClients []string `yaml:"allowedClients"`
+}
+
+// HTTPConfig tunes the client used for outbound api.video requests.
+type HTTPConfig struct {
+	Timeout    time.Duration `yaml:"timeout"`
+	MaxRetries int           `yaml:"maxRetries"`
+	RetryWait  time.Duration `yaml:"retryWait"` // initial backoff, doubled on each retry
+
+	// RequestsPerSecond limits outbound requests across all sessions; 0
+	// disables the limit. Burst is the number of requests allowed at once.
+	RequestsPerSecond float64 `yaml:"requestsPerSecond"`
+	Burst             int     `yaml:"burst"`
+
+	Cache CacheConfig `yaml:"cache"`
+}
+
+// CacheConfig controls the cache of api.video GET responses, shared by all
+// sessions and keyed by credentials. TTLs maps path patterns such as
+// "/videos/*" to how long their responses are used without asking api.video;
+// paths matching no pattern aren't cached. With Dir set, entries are also
+// kept on disk across restarts.
+type CacheConfig struct {
+	Enabled    bool                     `yaml:"enabled"`
+	Dir        string                   `yaml:"dir"`
+	MaxEntries int                      `yaml:"maxEntries"`
+	TTLs       map[string]time.Duration `yaml:"ttls"`
+}
+
+// ReloadConfig controls how often the configuration file and TLS certificates
+// are checked for changes. SIGHUP always triggers a reload.
+type ReloadConfig struct {
+	Interval time.Duration `yaml:"interval"` // 0 disables file watching
+}
+
+// ToolsConfig selects which tools are registered. Entries are glob patterns
+// matched against tool names, e.g. "delete_*". UnknownArguments says what to
+// do with arguments a tool doesn't declare: "reject" the call or "ignore"
+// them. DryRun makes every call return the api.video requests it would send
+// instead of sending them.
+type ToolsConfig struct {
+	Include          []string `yaml:"include"`
+	Exclude          []string `yaml:"exclude"`
+	UnknownArguments string   `yaml:"unknownArguments"`
+	DryRun           bool     `yaml:"dryRun"`
+}
+
+// MetricsConfig controls the Prometheus endpoint. In HTTP/HTTPS mode metrics
+// are served on Path of the main listener unless Address is set; in STDIO
+// mode they are only served when Address is set.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+	Address string `yaml:"address"` // separate listener, e.g. ":9090"
+}
+
+// TracingConfig selects the OpenTelemetry span exporter: none, otlp (OTLP
+// over HTTP), stdout (written to stderr, since stdout carries STDIO traffic)
+// or file. The standard OTEL_EXPORTER_OTLP_* variables are honoured as well.
+type TracingConfig struct {
+	Exporter    string            `yaml:"exporter"`
+	Endpoint    string            `yaml:"endpoint"` // OTLP endpoint URL, e.g. http://collector:4318
+	Headers     map[string]string `yaml:"headers"`  // extra OTLP request headers
+	File        string            `yaml:"file"`     // output path for the file exporter
+	ServiceName string            `yaml:"serviceName"`
+	SampleRatio float64           `yaml:"sampleRatio"` // fraction of new traces recorded, 0 to 1
+}
+
+// LoggingConfig controls the structured logs written to stderr. Tool argument
+// values and error bodies are left out unless LogBodies is set, and known
+// credential fields are always redacted.
+type LoggingConfig struct {
+	Level     string `yaml:"level"`  // debug, info, warn or error
+	Format    string `yaml:"format"` // json or text
+	LogBodies bool   `yaml:"logBodies"`
+}
+
+// HealthConfig controls the /readyz checks. Upstream results are cached for
+// CacheTTL so frequent probes don't consume the api.video rate limit.
+type HealthConfig struct {
+	CheckCredentials bool          `yaml:"checkCredentials"` // authenticate the api section and every profile
+	AccountProbe     bool          `yaml:"accountProbe"`     // also call GET /account
+	CacheTTL         time.Duration `yaml:"cacheTTL"`
+	Timeout          time.Duration `yaml:"timeout"`
+}
+
+// ShutdownConfig controls graceful shutdown. On SIGINT or SIGTERM new sessions
+// and tool calls are refused and running tool calls get DrainTimeout to finish
+// before they are cancelled.
+type ShutdownConfig struct {
+	DrainTimeout time.Duration `yaml:"drainTimeout"`
+}
+
+// ResourcesConfig controls MCP resource subscriptions, which are served by
+// polling api.video.
+type ResourcesConfig struct {
+	PollInterval time.Duration `yaml:"pollInterval"`
+}
+
+// WebhooksConfig controls the receiver of api.video webhook deliveries,
+// served on Path of the main listener in HTTP/HTTPS mode. Register its
+// public URL with post_webhooks. Deliveries must be signed with the secret
+// of a webhook registered for Path, fetched with the credentials of Profile
+// (empty selects the api section), or with one of Secrets. Events emitted
+// more than MaxSkew from now are refused. Only sessions using the base URL
+// and credentials of Profile, or whose verified client certificate matches
+// AllowedClients, can subscribe to the events.
+type WebhooksConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	Path           string        `yaml:"path"`
+	Profile        string        `yaml:"profile"`
+	Secrets        []string      `yaml:"secrets"`
+	MaxSkew        time.Duration `yaml:"maxSkew"`
+	StoreFile      string        `yaml:"storeFile"` // IDs of received events, kept across restarts
+	AllowedClients []string      `yaml:"allowedClients"`
+}
+
+// Default returns the configuration used when neither a file nor environment
+// variables set a value.
+func Default() *Config {
+	return &Config{
+		Transport: "stdio",
+		HTTP: HTTPConfig{
+			Timeout:    30 * time.Second,
+			MaxRetries: 2,
+			RetryWait:  500 * time.Millisecond,
+			// Bulk tools run up to 10 requests at once, so the limit is on
+			// unless turned off
+			RequestsPerSecond: 10,
+			Burst:             10,
+			Cache: CacheConfig{
+				MaxEntries: 1000,
+				TTLs: map[string]time.Duration{
+					"/account":        5 * time.Minute,
+					"/players/*":      5 * time.Minute,
+					"/webhooks/*":     5 * time.Minute,
+					"/videos/*":       30 * time.Second,
+					"/live-streams/*": 30 * time.Second,
+				},
+			},
+		},
+		Tools: ToolsConfig{
+			UnknownArguments: "reject",
+		},
+		Reload: ReloadConfig{
+			Interval: 10 * time.Second,
+		},
+		// Metrics reveal tool names, error types and traffic, so they are
+		// only served when asked for
+		Metrics: MetricsConfig{
+			Path: "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "apivideo-mcp-server",
+			SampleRatio: 1,
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Health: HealthConfig{
+			CheckCredentials: true,
+			CacheTTL:         30 * time.Second,
+			Timeout:          5 * time.Second,
+		},
+		Shutdown: ShutdownConfig{
+			DrainTimeout: 30 * time.Second,
+		},
+		Resources: ResourcesConfig{
+			PollInterval: 5 * time.Second,
+		},
+		Webhooks: WebhooksConfig{
+			Path:    "/webhooks/apivideo",
+			MaxSkew: 15 * time.Minute,
+		},
+	}
+}
+
+// Load reads the configuration file (if any), applies environment
+// variable overrides and validates the result. When file is empty the
+// CONFIG_FILE environment variable is used.
+func Load(file string) (*Config, error) {
+	cfg, err := Read(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Read is Load without validation, for callers that want to report every
+// problem themselves (such as --print-config).
+func Read(file string) (*Config, error) {
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
+	cfg := Default()
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		// JSON is a subset of YAML, so one decoder handles both formats.
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", file, err)
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	cfg.Transport = strings.ToLower(cfg.Transport)
+	return cfg, nil
+}
+
+// applyEnv overrides file values with environment variables.
+func (c *Config) applyEnv() error {
+	setString(&c.Transport, "TRANSPORT", "transport")
+	setString(&c.Port, "PORT", "port")
+	setString(&c.TLS.CertFile, "CERT_FILE")
+	setString(&c.TLS.KeyFile, "KEY_FILE")
+	setList(&c.TLS.ClientCAFiles, "TLS_CLIENT_CA_FILES")
+	setString(&c.TLS.ClientAuth, "TLS_CLIENT_AUTH")
+	setList(&c.TLS.AllowedClients, "TLS_ALLOWED_CLIENTS")
+	setString(&c.TLS.MinVersion, "TLS_MIN_VERSION")
+	setList(&c.TLS.CipherSuites, "TLS_CIPHER_SUITES")
+	setList(&c.TLS.NextProtos, "TLS_NEXT_PROTOS")
+	setString(&c.API.BaseURL, "API_BASE_URL")
+	setString(&c.API.BearerToken, "BEARER_TOKEN")
+	setString(&c.API.APIKey, "API_KEY")
+	setString(&c.API.BasicAuth, "BASIC_AUTH")
+	setString(&c.Profile, "API_PROFILE")
+	setList(&c.Tools.Include, "TOOLS_INCLUDE")
+	setList(&c.Tools.Exclude, "TOOLS_EXCLUDE")
+	setString(&c.Tools.UnknownArguments, "TOOLS_UNKNOWN_ARGUMENTS")
+	if err := setBool(&c.Tools.DryRun, "DRY_RUN"); err != nil {
+		return err
+	}
+	setString(&c.Metrics.Path, "METRICS_PATH")
+	setString(&c.Metrics.Address, "METRICS_ADDRESS")
+	if err := setBool(&c.Metrics.Enabled, "METRICS_ENABLED"); err != nil {
+		return err
+	}
+	setString(&c.Logging.Level, "LOG_LEVEL")
+	setString(&c.Logging.Format, "LOG_FORMAT")
+	if err := setBool(&c.Logging.LogBodies, "LOG_BODIES"); err != nil {
+		return err
+	}
+	if err := setBool(&c.Health.CheckCredentials, "HEALTH_CHECK_CREDENTIALS"); err != nil {
+		return err
+	}
+	if err := setBool(&c.Health.AccountProbe, "HEALTH_ACCOUNT_PROBE"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Health.CacheTTL, "HEALTH_CACHE_TTL"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Health.Timeout, "HEALTH_TIMEOUT"); err != nil {
+		return err
+	}
+	setString(&c.Tracing.Exporter, "TRACING_EXPORTER")
+	setString(&c.Tracing.Endpoint, "TRACING_ENDPOINT")
+	setString(&c.Tracing.File, "TRACING_FILE")
+	setString(&c.Tracing.ServiceName, "OTEL_SERVICE_NAME")
+	if err := setFloat(&c.Tracing.SampleRatio, "TRACING_SAMPLE_RATIO"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.Timeout, "HTTP_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.HTTP.RetryWait, "HTTP_RETRY_WAIT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Resources.PollInterval, "RESOURCES_POLL_INTERVAL"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Shutdown.DrainTimeout, "SHUTDOWN_DRAIN_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&c.Reload.Interval, "RELOAD_INTERVAL"); err != nil {
+		return err
+	}
+	if err := setFloat(&c.HTTP.RequestsPerSecond, "RATE_LIMIT_RPS"); err != nil {
+		return err
+	}
+	if err := setInt(&c.HTTP.Burst, "RATE_LIMIT_BURST"); err != nil {
+		return err
+	}
+	if err := setBool(&c.HTTP.Cache.Enabled, "CACHE_ENABLED"); err != nil {
+		return err
+	}
+	setString(&c.HTTP.Cache.Dir, "CACHE_DIR")
+	if err := setInt(&c.HTTP.Cache.MaxEntries, "CACHE_MAX_ENTRIES"); err != nil {
+		return err
+	}
+	if err := setBool(&c.Webhooks.Enabled, "WEBHOOKS_ENABLED"); err != nil {
+		return err
+	}
+	setString(&c.Webhooks.Path, "WEBHOOKS_PATH")
+	setString(&c.Webhooks.Profile, "WEBHOOKS_PROFILE")
+	setList(&c.Webhooks.Secrets, "WEBHOOKS_SECRETS")
+	if err := setDuration(&c.Webhooks.MaxSkew, "WEBHOOKS_MAX_SKEW"); err != nil {
+		return err
+	}
+	setString(&c.Webhooks.StoreFile, "WEBHOOKS_STORE_FILE")
+	setList(&c.Webhooks.AllowedClients, "WEBHOOKS_ALLOWED_CLIENTS")
+	return setInt(&c.HTTP.MaxRetries, "HTTP_MAX_RETRIES")
 }
 
-func LoadAPIConfig() (*APIConfig, error) {
-	// Check port environment variable (both uppercase and lowercase)
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = os.Getenv("port")
+// lookupEnv returns the first non-empty variable among names. Lowercase
+// aliases are kept for backwards compatibility.
+func lookupEnv(names ...string) (string, bool) {
+	for _, name := range names {
+		if val := os.Getenv(name); val != "" {
+			return val, true
+		}
 	}
-	
-	baseURL := os.Getenv("API_BASE_URL")
-	
-	// Check transport environment variable (both uppercase and lowercase)
-	transport := os.Getenv("TRANSPORT")
-	if transport == "" {
-		transport = os.Getenv("transport")
+	return "", false
+}
+
+func setString(dst *string, names ...string) {
+	if val, ok := lookupEnv(names...); ok {
+		*dst = val
+	}
+}
+
+func setList(dst *[]string, name string) {
+	val, ok := lookupEnv(name)
+	if !ok {
+		return
+	}
+	*dst = nil
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*dst = append(*dst, item)
+		}
+	}
+}
+
+func setDuration(dst *time.Duration, name string) error {
+	val, ok := lookupEnv(name)
+	if !ok {
+		return nil
 	}
-	
-	// For STDIO mode (transport is not "http"/"HTTP"/"https"/"HTTPS"), API_BASE_URL is required from environment
-	if transport != "http" && transport != "HTTP" && transport != "https" && transport != "HTTPS" && baseURL == "" {
-		return nil, fmt.Errorf("API_BASE_URL environment variable not set")
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, val, err)
 	}
-	
-	// For HTTP/HTTPS mode (transport is "http"/"HTTP"/"https"/"HTTPS"), API_BASE_URL comes from headers
-	// so we don't require it from environment variables
+	*dst = d
+	return nil
+}
 
+func setInt(dst *int, name string) error {
+	val, ok := lookupEnv(name)
+	if !ok {
+		return nil
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, val, err)
+	}
+	*dst = n
+	return nil
+}
+
+func setBool(dst *bool, name string) error {
+	val, ok := lookupEnv(name)
+	if !ok {
+		return nil
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, val, err)
+	}
+	*dst = b
+	return nil
+}
+
+func setFloat(dst *float64, name string) error {
+	val, ok := lookupEnv(name)
+	if !ok {
+		return nil
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, val, err)
+	}
+	*dst = f
+	return nil
+}
+
+// IsHTTP reports whether the server runs in HTTP or HTTPS mode.
+func (c *Config) IsHTTP() bool {
+	return c.Transport == "http" || c.Transport == "https"
+}
+
+// APIConfig returns the api.video settings used in STDIO mode: the selected
+// profile, if any, on top of the api section.
+func (c *Config) APIConfig() *APIConfig {
+	apiCfg, _ := c.ProfileConfig(c.Profile)
+	return apiCfg
+}
+
+// ProfileConfig returns the api.video settings of the named profile on top of
+// the api section. An empty name selects the api section alone.
+func (c *Config) ProfileConfig(name string) (*APIConfig, bool) {
+	section := c.API
+	if name != "" {
+		profile, ok := c.Profiles[name]
+		if !ok {
+			return nil, false
+		}
+		overlay(&section.BaseURL, profile.BaseURL)
+		overlay(&section.BearerToken, profile.BearerToken)
+		overlay(&section.APIKey, profile.APIKey)
+		overlay(&section.BasicAuth, profile.BasicAuth)
+	}
 	return &APIConfig{
-		BaseURL:     baseURL,
-		BearerToken: os.Getenv("BEARER_TOKEN"),
-		APIKey:      os.Getenv("API_KEY"),
-		BasicAuth:   os.Getenv("BASIC_AUTH"),
-		Port:        port,
-	}, nil
+		BaseURL:     section.BaseURL,
+		BearerToken: section.BearerToken,
+		APIKey:      section.APIKey,
+		BasicAuth:   section.BasicAuth,
+	}, true
+}
+
+func overlay(dst *string, val string) {
+	if val != "" {
+		*dst = val
+	}
+}
+
+// Allows reports whether a tool passes the include and exclude filters.
+func (t ToolsConfig) Allows(name string) bool {
+	if len(t.Include) > 0 && !matchAny(t.Include, name) {
+		return false
+	}
+	return !matchAny(t.Exclude, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
 }
 
+// Redacted returns a YAML dump of the configuration with secrets masked.
+func (c *Config) Redacted() ([]byte, error) {
+	cp := *c
+	cp.API = cp.API.redacted()
+	cp.Profiles = make(map[string]ProfileSection, len(c.Profiles))
+	for name, profile := range c.Profiles {
+		profile.APISection = profile.APISection.redacted()
+		cp.Profiles[name] = profile
+	}
+	cp.Webhooks.Secrets = make([]string, len(c.Webhooks.Secrets))
+	for i, secret := range c.Webhooks.Secrets {
+		cp.Webhooks.Secrets[i] = redact(secret)
+	}
+	return yaml.Marshal(&cp)
+}
 
+func (s APISection) redacted() APISection {
+	s.BearerToken = redact(s.BearerToken)
+	s.APIKey = redact(s.APIKey)
+	s.BasicAuth = redact(s.BasicAuth)
+	return s
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "REDACTED"
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify-if-given":    tls.VerifyClientCertIfGiven,
+	"require-and-verify": tls.RequireAndVerifyClientCert,
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// clientAuthType resolves ClientAuth, defaulting to require-and-verify when
+// client CAs are configured and to none otherwise.
+func (t TLSConfig) clientAuthType() (tls.ClientAuthType, bool) {
+	name := t.ClientAuth
+	if name == "" {
+		name = "none"
+		if len(t.ClientCAFiles) > 0 {
+			name = "require-and-verify"
+		}
+	}
+	authType, ok := clientAuthTypes[name]
+	return authType, ok
+}
+
+func cipherSuite(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
+
+// ServerConfig loads the certificate and client CA bundles and returns the
+// TLS settings for the HTTPS listener.
+func (t TLSConfig) ServerConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	authType, ok := t.clientAuthType()
+	if !ok {
+		return nil, fmt.Errorf("unknown tls.clientAuth %q", t.ClientAuth)
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   authType,
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   t.NextProtos,
+	}
+	if len(tlsCfg.NextProtos) == 0 {
+		tlsCfg.NextProtos = []string{"h2", "http/1.1"}
+	}
+	if t.MinVersion != "" {
+		tlsCfg.MinVersion = tlsVersions[t.MinVersion]
+	}
+	for _, name := range t.CipherSuites {
+		id, ok := cipherSuite(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown TLS cipher suite %q", name)
+		}
+		tlsCfg.CipherSuites = append(tlsCfg.CipherSuites, id)
+	}
+	if len(t.ClientCAFiles) > 0 {
+		tlsCfg.ClientCAs = x509.NewCertPool()
+		for _, file := range t.ClientCAFiles {
+			pem, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read client CA bundle: %w", err)
+			}
+			if !tlsCfg.ClientCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in client CA bundle %s", file)
+			}
+		}
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Validate checks the configuration and reports every problem found, one per
+// line, prefixed with the key it concerns.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	switch c.Transport {
+	case "stdio", "http", "https":
+	default:
+		fail("transport", "must be one of stdio, http, https (got %q)", c.Transport)
+	}
+
+	if c.IsHTTP() {
+		if c.Port == "" {
+			fail("port", "is required in %s mode (set PORT)", c.Transport)
+		} else if n, err := strconv.Atoi(c.Port); err != nil || n < 1 || n > 65535 {
+			fail("port", "must be a number between 1 and 65535 (got %q)", c.Port)
+		}
+	}
+
+	if c.Transport == "https" {
+		if c.TLS.CertFile == "" {
+			fail("tls.certFile", "is required in https mode (set CERT_FILE)")
+		}
+		if c.TLS.KeyFile == "" {
+			fail("tls.keyFile", "is required in https mode (set KEY_FILE)")
+		}
+	}
+	if authType, ok := c.TLS.clientAuthType(); !ok {
+		fail("tls.clientAuth", "must be one of none, request, require, verify-if-given, require-and-verify (got %q)", c.TLS.ClientAuth)
+	} else if (authType == tls.VerifyClientCertIfGiven || authType == tls.RequireAndVerifyClientCert) && len(c.TLS.ClientCAFiles) == 0 {
+		fail("tls.clientCAFiles", "is required when tls.clientAuth is %s", c.TLS.ClientAuth)
+	}
+	if len(c.TLS.AllowedClients) > 0 && len(c.TLS.ClientCAFiles) == 0 {
+		fail("tls.allowedClients", "needs tls.clientCAFiles to verify client certificates")
+	}
+	if _, ok := tlsVersions[c.TLS.MinVersion]; c.TLS.MinVersion != "" && !ok {
+		fail("tls.minVersion", "must be 1.2 or 1.3 (got %q)", c.TLS.MinVersion)
+	}
+	for _, name := range c.TLS.CipherSuites {
+		if _, ok := cipherSuite(name); !ok {
+			fail("tls.cipherSuites", "unknown or insecure cipher suite %q", name)
+		}
+	}
+
+	if c.Profile != "" {
+		if _, ok := c.Profiles[c.Profile]; !ok {
+			fail("profile", "unknown profile %q", c.Profile)
+		}
+	}
+	// In HTTP/HTTPS mode API_BASE_URL may come from each request's headers.
+	if apiCfg := c.APIConfig(); apiCfg != nil && apiCfg.BaseURL == "" && !c.IsHTTP() {
+		fail("api.baseURL", "is required in stdio mode (set API_BASE_URL)")
+	}
+	checkURL := func(key, val string) {
+		if u, err := url.Parse(val); val != "" && (err != nil || u.Scheme == "" || u.Host == "") {
+			fail(key, "must be an absolute URL (got %q)", val)
+		}
+	}
+	checkURL("api.baseURL", c.API.BaseURL)
+	for _, name := range slices.Sorted(maps.Keys(c.Profiles)) {
+		checkURL("profiles."+name+".baseURL", c.Profiles[name].BaseURL)
+		if len(c.Profiles[name].AllowedClients) > 0 && len(c.TLS.ClientCAFiles) == 0 {
+			fail("profiles."+name+".allowedClients", "needs tls.clientCAFiles to verify client certificates")
+		}
+	}
+
+	if c.HTTP.Timeout < 0 {
+		fail("http.timeout", "must not be negative")
+	}
+	if c.HTTP.MaxRetries < 0 {
+		fail("http.maxRetries", "must not be negative")
+	}
+	if c.HTTP.RetryWait < 0 {
+		fail("http.retryWait", "must not be negative")
+	}
+	if c.HTTP.RequestsPerSecond < 0 {
+		fail("http.requestsPerSecond", "must not be negative")
+	}
+	if c.HTTP.RequestsPerSecond > 0 && c.HTTP.Burst < 1 {
+		fail("http.burst", "must be at least 1 when http.requestsPerSecond is set")
+	}
+	if c.HTTP.Cache.Enabled && c.HTTP.Cache.MaxEntries < 1 {
+		fail("http.cache.maxEntries", "must be at least 1 when the cache is enabled")
+	}
+	for _, pattern := range slices.Sorted(maps.Keys(c.HTTP.Cache.TTLs)) {
+		if _, err := path.Match(pattern, "/"); err != nil || !strings.HasPrefix(pattern, "/") {
+			fail("http.cache.ttls", "%q must be a path pattern such as /videos/*", pattern)
+		} else if c.HTTP.Cache.TTLs[pattern] < 0 {
+			fail("http.cache.ttls", "TTL of %s must not be negative", pattern)
+		}
+	}
+	if c.Health.CacheTTL < 0 {
+		fail("health.cacheTTL", "must not be negative")
+	}
+	if c.Health.Timeout <= 0 {
+		fail("health.timeout", "must be positive")
+	}
+	if c.Resources.PollInterval <= 0 {
+		fail("resources.pollInterval", "must be positive")
+	}
+	if c.Shutdown.DrainTimeout < 0 {
+		fail("shutdown.drainTimeout", "must not be negative")
+	}
+	if c.Reload.Interval < 0 {
+		fail("reload.interval", "must not be negative")
+	}
+
+	if c.Metrics.Enabled {
+		if !strings.HasPrefix(c.Metrics.Path, "/") || c.Metrics.Path == "/" || c.Metrics.Path == "/mcp" {
+			fail("metrics.path", "must be an absolute path other than / and /mcp (got %q)", c.Metrics.Path)
+		}
+		if _, _, err := net.SplitHostPort(c.Metrics.Address); c.Metrics.Address != "" && err != nil {
+			fail("metrics.address", "must be host:port (got %q)", c.Metrics.Address)
+		}
+	}
+
+	if c.Webhooks.Enabled {
+		reserved := []string{"/", "/mcp", "/healthz", "/readyz"}
+		if c.Metrics.Enabled && c.Metrics.Address == "" {
+			reserved = append(reserved, c.Metrics.Path)
+		}
+		if !strings.HasPrefix(c.Webhooks.Path, "/") || slices.Contains(reserved, c.Webhooks.Path) {
+			fail("webhooks.path", "must be an absolute path other than %s (got %q)", strings.Join(reserved, ", "), c.Webhooks.Path)
+		}
+		if c.Webhooks.MaxSkew <= 0 {
+			fail("webhooks.maxSkew", "must be positive")
+		}
+		// Deliveries are only trusted when signed with a known secret
+		if apiCfg, ok := c.ProfileConfig(c.Webhooks.Profile); !ok {
+			fail("webhooks.profile", "unknown profile %q", c.Webhooks.Profile)
+		} else if len(c.Webhooks.Secrets) == 0 && (apiCfg.BaseURL == "" || apiCfg.BearerToken == "" && apiCfg.APIKey == "" && apiCfg.BasicAuth == "") {
+			fail("webhooks.secrets", "are required unless the api section or webhooks.profile has a base URL and credentials to fetch the webhook secrets")
+		}
+		if len(c.Webhooks.AllowedClients) > 0 && len(c.TLS.ClientCAFiles) == 0 {
+			fail("webhooks.allowedClients", "needs tls.clientCAFiles to verify client certificates")
+		}
+	}
+
+	switch strings.ToLower(c.Logging.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		fail("logging.level", "must be one of debug, info, warn, error (got %q)", c.Logging.Level)
+	}
+	if c.Logging.Format != "json" && c.Logging.Format != "text" {
+		fail("logging.format", "must be json or text (got %q)", c.Logging.Format)
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "otlp", "stdout":
+	case "file":
+		if c.Tracing.File == "" {
+			fail("tracing.file", "is required with the file exporter (set TRACING_FILE)")
+		}
+	default:
+		fail("tracing.exporter", "must be one of none, otlp, stdout, file (got %q)", c.Tracing.Exporter)
+	}
+	checkURL("tracing.endpoint", c.Tracing.Endpoint)
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sampleRatio", "must be between 0 and 1")
+	}
+
+	// Client patterns are matched one "/" segment at a time, so each segment
+	// must be a valid pattern on its own
+	checkPatterns := func(key string, patterns []string) {
+		for _, pattern := range patterns {
+			for _, segment := range strings.Split(pattern, "/") {
+				if _, err := path.Match(segment, ""); err != nil {
+					fail(key, "invalid pattern %q", pattern)
+					break
+				}
+			}
+		}
+	}
+	checkPatterns("tls.allowedClients", c.TLS.AllowedClients)
+	for _, name := range slices.Sorted(maps.Keys(c.Profiles)) {
+		checkPatterns("profiles."+name+".allowedClients", c.Profiles[name].AllowedClients)
+	}
+	checkPatterns("webhooks.allowedClients", c.Webhooks.AllowedClients)
+	checkPatterns("tools.include", c.Tools.Include)
+	checkPatterns("tools.exclude", c.Tools.Exclude)
+	if c.Tools.UnknownArguments != "reject" && c.Tools.UnknownArguments != "ignore" {
+		fail("tools.unknownArguments", "must be reject or ignore (got %q)", c.Tools.UnknownArguments)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+}
@@ -0,0 +1,155 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/api-video/mcp-server/logging"
+	"github.com/api-video/mcp-server/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxBody caps the request body shown for one request, so an upload doesn't
+// flood the result.
+const maxBody = 64 << 10
+
+// Request is an api.video request rendered instead of sent.
+type Request struct {
+	Method  string         `json:"method"`
+	URL     string         `json:"url"`
+	Headers map[string]any `json:"headers"`
+	Body    any            `json:"body,omitempty"`
+}
+
+// recorder collects the requests of one dry-run call.
+type recorder struct {
+	mu       sync.Mutex
+	requests []Request
+}
+
+type recorderKey struct{}
+
+// Transport answers the requests of dry-run calls itself with an empty JSON
+// object, recording them, and sends the other requests through Next.
+type Transport struct {
+	Next http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec, ok := req.Context().Value(recorderKey{}).(*recorder)
+	if !ok {
+		return t.Next.RoundTrip(req)
+	}
+	rendered, err := render(req)
+	if err != nil {
+		return nil, err
+	}
+	rec.mu.Lock()
+	rec.requests = append(rec.requests, rendered)
+	rec.mu.Unlock()
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(strings.NewReader("{}")),
+		ContentLength: 2,
+		Request:       req,
+	}, nil
+}
+
+// render describes a request with its credentials redacted.
+func render(req *http.Request) (Request, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	headers := map[string]any{}
+	for name, values := range req.Header {
+		headers[name] = strings.Join(values, ", ")
+	}
+	rendered := Request{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: logging.Redact(headers).(map[string]any),
+	}
+	if req.Body == nil {
+		return rendered, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBody+1))
+	if err != nil {
+		return rendered, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	var decoded any
+	switch {
+	case len(body) == 0:
+	case len(body) > maxBody:
+		rendered.Body = string(body[:maxBody]) + "..."
+	case mediaType == "application/json" && json.Unmarshal(body, &decoded) == nil:
+		rendered.Body = logging.Redact(decoded)
+	default:
+		rendered.Body = string(body)
+	}
+	return rendered, nil
+}
+
+// Enabled reports whether requests made with ctx are only recorded, so tools
+// that wait on api.video can stop after their first request.
+func Enabled(ctx context.Context) bool {
+	_, ok := ctx.Value(recorderKey{}).(*recorder)
+	return ok
+}
+
+// Tool adds the dryRun argument to a tool definition.
+func Tool(tool mcp.Tool) mcp.Tool {
+	tool.InputSchema.Properties = maps.Clone(tool.InputSchema.Properties)
+	mcp.WithBoolean("dryRun", mcp.Description("Return the api.video requests the call would make, with credentials redacted, instead of sending them."))(&tool)
+	return tool
+}
+
+// Handler runs handler in dry-run mode when always is set or the call passes
+// dryRun: true. In dry-run mode no request reaches api.video: each gets an
+// empty response, and the result lists the requests made.
+func Handler(handler server.ToolHandlerFunc, always bool) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		dryRun := always
+		if args, ok := request.Params.Arguments.(map[string]any); ok {
+			if _, ok := args["dryRun"]; ok {
+				// The argument was validated against the schema Tool adds
+				dryRun = dryRun || args["dryRun"] == true
+				args = maps.Clone(args)
+				delete(args, "dryRun")
+				request.Params.Arguments = args
+			}
+		}
+		if !dryRun {
+			return handler(ctx, request)
+		}
+
+		rec := &recorder{}
+		result, err := handler(context.WithValue(ctx, recorderKey{}, rec), request)
+		if err != nil || len(rec.requests) == 0 {
+			// Rejected before any request, e.g. invalid arguments
+			return result, err
+		}
+		out := map[string]any{"dryRun": true, "requests": rec.requests}
+		if result != nil && result.IsError {
+			// Later requests needed a real response to be built
+			out["stoppedWith"] = tools.ResultText(result)
+		}
+		prettyJSON, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+		}
+		return mcp.NewToolResultText(string(prettyJSON)), nil
+	}
+}
@@ -0,0 +1,242 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+)
+
+// Check is the outcome of a single readiness check.
+type Check struct {
+	Status    string `json:"status"` // ok, failed or skipped
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latencyMs,omitempty"`
+	CheckedAt string `json:"checkedAt,omitempty"`
+}
+
+// Report is the body returned by /readyz.
+type Report struct {
+	Status string           `json:"status"` // ready or not_ready
+	Checks map[string]Check `json:"checks"`
+}
+
+// Checker runs the readiness checks against the current configuration and
+// caches upstream results so probes don't hit api.video on every request.
+// The cache is dropped when the configuration is reloaded.
+type Checker struct {
+	Config   func() *config.Config
+	Client   func() *http.Client
+	Draining func() bool        // optional, reports a shutdown in progress
+	TLS      func() *tls.Config // optional, the settings served in HTTPS mode
+
+	mu     sync.Mutex
+	config *config.Config // configuration the cached results belong to
+	cached map[string]*cachedCheck
+}
+
+// cachedCheck is the result of a check, or a check still running when done
+// isn't closed yet; concurrent probes wait for it instead of running it again.
+type cachedCheck struct {
+	check   Check
+	expires time.Time
+	done    chan struct{}
+}
+
+// Liveness answers /healthz: the process is up and serving requests.
+func Liveness(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"status":"ok"}`))
+}
+
+// ServeHTTP answers /readyz with the report, using status 503 when any check
+// failed.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := c.Run(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status != "ready" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// Run performs every readiness check.
+func (c *Checker) Run(ctx context.Context) Report {
+	cfg := c.Config()
+	report := Report{Status: "ready", Checks: map[string]Check{}}
+
+	if c.TLS != nil {
+		report.Checks["tls"] = c.cachedRun(ctx, cfg, "tls", func(context.Context) error {
+			return checkCertificate(c.TLS(), time.Now())
+		})
+	}
+
+	if cfg.Health.CheckCredentials {
+		// The api section (and STDIO profile) plus every named profile
+		targets := map[string]*config.APIConfig{"credentials": cfg.APIConfig()}
+		for _, name := range slices.Sorted(maps.Keys(cfg.Profiles)) {
+			targets["credentials:"+name], _ = cfg.ProfileConfig(name)
+		}
+		for name, apiCfg := range targets {
+			report.Checks[name] = c.cachedRun(ctx, cfg, name, func(ctx context.Context) error {
+				return authenticate(ctx, c.Client(), apiCfg)
+			})
+		}
+	}
+
+	if cfg.Health.AccountProbe {
+		report.Checks["account"] = c.cachedRun(ctx, cfg, "account", func(ctx context.Context) error {
+			return probeAccount(ctx, c.Client(), cfg.APIConfig())
+		})
+	}
+
+	if c.Draining != nil && c.Draining() {
+		report.Checks["shutdown"] = Check{Status: "failed", Error: "server is draining"}
+	}
+
+	for _, check := range report.Checks {
+		if check.Status == "failed" {
+			report.Status = "not_ready"
+		}
+	}
+	return report
+}
+
+// errSkipped marks a check that had nothing to verify.
+var errSkipped = fmt.Errorf("skipped")
+
+// cachedRun returns the cached result of the check named key for cfg, or runs
+// it. The lock isn't held while the check runs, so a slow upstream only
+// delays the probes waiting for that check.
+func (c *Checker) cachedRun(ctx context.Context, cfg *config.Config, key string, run func(context.Context) error) Check {
+	c.mu.Lock()
+	if c.config != cfg {
+		c.config, c.cached = cfg, map[string]*cachedCheck{}
+	}
+	if cached, ok := c.cached[key]; ok {
+		select {
+		case <-cached.done:
+			if time.Now().Before(cached.expires) {
+				c.mu.Unlock()
+				return cached.check
+			}
+		default:
+			c.mu.Unlock()
+			select {
+			case <-cached.done:
+				return cached.check
+			case <-ctx.Done():
+				return Check{Status: "failed", Error: ctx.Err().Error()}
+			}
+		}
+	}
+	entry := &cachedCheck{done: make(chan struct{})}
+	c.cached[key] = entry
+	c.mu.Unlock()
+
+	// The result is shared, so it doesn't end with the probe that started it
+	ctx, cancel := context.WithTimeout(metrics.WithOperation(context.WithoutCancel(ctx), "readiness"), cfg.Health.Timeout)
+	defer cancel()
+	start := time.Now()
+	err := run(ctx)
+	check := Check{Status: "ok", LatencyMS: time.Since(start).Milliseconds(), CheckedAt: start.UTC().Format(time.RFC3339)}
+	switch {
+	case err == errSkipped:
+		check = Check{Status: "skipped"}
+	case err != nil:
+		check.Status, check.Error = "failed", err.Error()
+	}
+
+	c.mu.Lock()
+	entry.check, entry.expires = check, time.Now().Add(cfg.Health.CacheTTL)
+	c.mu.Unlock()
+	close(entry.done)
+	return check
+}
+
+// checkCertificate verifies that the certificate served with tlsCfg is valid
+// at now. A reload only replaces it with certificate files that load, so an
+// expired certificate stays in use until valid files are deployed.
+func checkCertificate(tlsCfg *tls.Config, now time.Time) error {
+	if tlsCfg == nil || len(tlsCfg.Certificates) == 0 {
+		return errSkipped
+	}
+	leaf := tlsCfg.Certificates[0].Leaf
+	if leaf == nil {
+		var err error
+		if leaf, err = x509.ParseCertificate(tlsCfg.Certificates[0].Certificate[0]); err != nil {
+			return err
+		}
+	}
+	switch {
+	case now.After(leaf.NotAfter):
+		return fmt.Errorf("certificate expired at %s", leaf.NotAfter.UTC().Format(time.RFC3339))
+	case now.Before(leaf.NotBefore):
+		return fmt.Errorf("certificate not valid before %s", leaf.NotBefore.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// authenticate verifies credentials: API keys are exchanged for a token with
+// POST /auth/api-key, bearer tokens and basic credentials are tried on
+// GET /account.
+func authenticate(ctx context.Context, client *http.Client, apiCfg *config.APIConfig) error {
+	if apiCfg.BaseURL == "" || (apiCfg.APIKey == "" && apiCfg.BearerToken == "" && apiCfg.BasicAuth == "") {
+		return errSkipped
+	}
+	if apiCfg.APIKey == "" {
+		return probeAccount(ctx, client, apiCfg)
+	}
+	body, _ := json.Marshal(map[string]string{"apiKey": apiCfg.APIKey})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiCfg.BaseURL+"/auth/api-key", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(client, req)
+}
+
+// probeAccount calls GET /account with the configured credentials.
+func probeAccount(ctx context.Context, client *http.Client, apiCfg *config.APIConfig) error {
+	if apiCfg.BaseURL == "" {
+		return errSkipped
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiCfg.BaseURL+"/account", nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case apiCfg.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+apiCfg.BearerToken)
+	case apiCfg.BasicAuth != "":
+		req.Header.Set("Authorization", "Basic "+apiCfg.BasicAuth)
+	case apiCfg.APIKey != "":
+		req.SetBasicAuth(apiCfg.APIKey, "")
+	}
+	return do(client, req)
+}
+
+func do(client *http.Client, req *http.Request) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+	return nil
+}
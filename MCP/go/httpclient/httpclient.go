@@ -0,0 +1,133 @@
+package httpclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/api-video/mcp-server/cache"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/dryrun"
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/tracing"
+	"golang.org/x/time/rate"
+)
+
+// New returns the client used by tool handlers for api.video requests.
+func New(cfg config.HTTPConfig) *http.Client {
+	var next http.RoundTripper = tracing.Transport(&metrics.Transport{Next: http.DefaultTransport})
+	if cfg.RequestsPerSecond > 0 {
+		next = &limitTransport{
+			next:    next,
+			limiter: rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst),
+		}
+	}
+	next = &retryTransport{
+		next:       next,
+		maxRetries: cfg.MaxRetries,
+		wait:       cfg.RetryWait,
+	}
+	if cfg.Cache.Enabled {
+		// Cached responses skip the limiter and don't count as upstream requests
+		next = cache.New(cfg.Cache, next)
+	}
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		// Dry-run requests are answered before any cache, limit, retry or metric
+		Transport: &dryrun.Transport{Next: next},
+	}
+}
+
+// limitTransport holds each request, retries included, until the limiter
+// allows it so the server stays under the api.video rate limit.
+type limitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reservation := t.limiter.Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		metrics.RateLimited.WithLabelValues(metrics.Operation(req.Context()), "local").Inc()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			reservation.Cancel()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// retryTransport retries throttled (429) and unavailable (503) responses, and
+// for idempotent methods also network errors and 502/504 responses. It backs
+// off exponentially, or for as long as the Retry-After header asks.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	wait       time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	wait := t.wait
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !retryable(req, resp, err) || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+		delay := wait
+		if resp != nil {
+			if after := retryAfter(resp); after > 0 {
+				delay = after
+			}
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		wait *= 2
+		metrics.UpstreamRetries.WithLabelValues(metrics.Operation(req.Context())).Inc()
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodDelete
+	if err != nil {
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// retryAfter reads the Retry-After header, given either as delta-seconds or
+// as an HTTP date.
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
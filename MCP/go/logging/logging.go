@@ -0,0 +1,168 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/api-video/mcp-server/config"
+)
+
+var level = new(slog.LevelVar)
+
+// logBodies is read by the tool middleware on every call so reloads apply.
+var logBodies atomic.Bool
+
+// output formats the records, replaced by Apply so a reload can switch
+// between JSON and text; out is where it writes.
+var (
+	output atomic.Pointer[slog.Handler]
+	out    io.Writer
+)
+
+// Setup installs the default slog logger configured by cfg, writing to w.
+// Call Apply after a reload to change the level, format and body logging.
+func Setup(cfg config.LoggingConfig, w io.Writer) {
+	out = w
+	Apply(cfg)
+	slog.SetDefault(slog.New(&contextHandler{Handler: &formatHandler{}}))
+}
+
+// Apply updates the settings that can change at runtime.
+func Apply(cfg config.LoggingConfig) {
+	level.Set(ParseLevel(cfg.Level))
+	logBodies.Store(cfg.LogBodies)
+
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(out, opts)
+	} else {
+		handler = slog.NewJSONHandler(out, opts)
+	}
+	output.Store(&handler)
+}
+
+// ParseLevel maps debug, info, warn and error to slog levels, defaulting to info.
+func ParseLevel(name string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+type attrsKey struct{}
+
+// With returns a copy of ctx whose log lines carry attrs in addition to any
+// attributes already attached, e.g. the request or session ID.
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return context.WithValue(ctx, attrsKey{}, append(existing[:len(existing):len(existing)], attrs...))
+}
+
+// has reports whether an attribute with key is already attached to ctx.
+func has(ctx context.Context, key string) bool {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return slices.ContainsFunc(attrs, func(a slog.Attr) bool { return a.Key == key })
+}
+
+// contextHandler adds the attributes attached with With to every record
+// logged with a context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// formatHandler writes records with the current output handler. Attributes
+// and groups added to a logger are kept as steps and replayed on it for each
+// record, so loggers created before a format change follow it too.
+type formatHandler struct {
+	steps []func(slog.Handler) slog.Handler
+}
+
+func (h *formatHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= level.Level()
+}
+
+func (h *formatHandler) Handle(ctx context.Context, r slog.Record) error {
+	handler := *output.Load()
+	for _, step := range h.steps {
+		handler = step(handler)
+	}
+	return handler.Handle(ctx, r)
+}
+
+func (h *formatHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *formatHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *formatHandler) with(step func(slog.Handler) slog.Handler) *formatHandler {
+	return &formatHandler{steps: append(h.steps[:len(h.steps):len(h.steps)], step)}
+}
+
+// sensitive reports whether a key names a credential or other secret.
+func sensitive(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, word := range []string{"authorization", "token", "apikey", "basicauth", "password", "secret", "streamkey"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if sensitive(a.Key) && a.Value.Kind() != slog.KindGroup {
+		return slog.String(a.Key, "REDACTED")
+	}
+	return a
+}
+
+// Redact returns a copy of v, a decoded JSON value, with the values of
+// sensitive keys replaced at any depth.
+func Redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, val := range v {
+			if sensitive(key) {
+				out[key] = "REDACTED"
+			} else {
+				out[key] = Redact(val)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = Redact(val)
+		}
+		return out
+	}
+	return v
+}
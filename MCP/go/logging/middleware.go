@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// HTTPMiddleware attaches a request ID, taken from X-Request-Id or generated,
+// and the MCP session ID to the context of every /mcp request, and echoes the
+// request ID back in the response.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get("X-Request-Id")
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set("X-Request-Id", requestID)
+
+		attrs := []slog.Attr{slog.String("request_id", requestID)}
+		if sessionID := r.Header.Get(server.HeaderKeySessionID); sessionID != "" {
+			attrs = append(attrs, slog.String("session_id", sessionID))
+		}
+		ctx := With(r.Context(), attrs...)
+
+		start := time.Now()
+		next.ServeHTTP(w, r.WithContext(ctx))
+		slog.DebugContext(ctx, "http request", "method", r.Method, "path", r.URL.Path, "duration_ms", time.Since(start).Milliseconds())
+	})
+}
+
+// ToolMiddleware logs one entry per tool call with its duration and outcome.
+// Argument values and error bodies are only logged when body logging is on,
+// and even then with credentials redacted.
+func ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		attrs := []slog.Attr{slog.String("call_id", uuid.NewString())}
+		if session := server.ClientSessionFromContext(ctx); session != nil && session.SessionID() != "" && !has(ctx, "session_id") {
+			attrs = append(attrs, slog.String("session_id", session.SessionID()))
+		}
+		ctx = With(ctx, attrs...)
+
+		tool := request.Params.Name
+		args, _ := request.Params.Arguments.(map[string]any)
+		start := time.Now()
+		result, err := next(ctx, request)
+
+		fields := []any{
+			slog.String("tool", tool),
+			slog.Any("argument_keys", slices.Sorted(maps.Keys(args))),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+		}
+		if logBodies.Load() {
+			fields = append(fields, slog.Any("arguments", Redact(map[string]any(args))))
+		}
+		switch {
+		case err != nil:
+			slog.ErrorContext(ctx, "tool call failed", append(fields, slog.String("error", err.Error()))...)
+		case result != nil && result.IsError:
+			if logBodies.Load() {
+				fields = append(fields, slog.String("result", resultText(result)))
+			}
+			slog.WarnContext(ctx, "tool call returned an error", fields...)
+		default:
+			slog.InfoContext(ctx, "tool call", fields...)
+		}
+		return result, err
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"cmp"
 	"context"
-	"log"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
@@ -10,136 +15,352 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/mark3labs/mcp-go/server"
+	"github.com/api-video/mcp-server/auth"
+	"github.com/api-video/mcp-server/completion"
 	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/dryrun"
+	"github.com/api-video/mcp-server/health"
+	"github.com/api-video/mcp-server/logging"
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/prompts"
+	"github.com/api-video/mcp-server/resources"
+	"github.com/api-video/mcp-server/rpc"
+	"github.com/api-video/mcp-server/shutdown"
+	"github.com/api-video/mcp-server/tracing"
+	"github.com/api-video/mcp-server/validate"
+	"github.com/api-video/mcp-server/webhooks"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
-	cfg, err := config.LoadAPIConfig()
+	configFile := flag.String("config", "", "Path to a YAML or JSON configuration file (defaults to $CONFIG_FILE)")
+	printConfig := flag.Bool("print-config", false, "Print the resolved configuration with secrets redacted and exit")
+	flag.Parse()
+
+	if *printConfig {
+		os.Exit(runPrintConfig(*configFile))
+	}
+
+	state, err := newServerState(*configFile)
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("Failed to load config", err)
 	}
+	cfg := state.Config()
+	// Stdout carries the protocol in STDIO mode, so logs always go to stderr
+	logging.Setup(cfg.Logging, os.Stderr)
 
-	// Check transport environment variable (both uppercase and lowercase)
-	transport := os.Getenv("TRANSPORT")
-	if transport == "" {
-		transport = os.Getenv("transport")
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
 	}
+	defer shutdownTracing(context.Background())
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	go state.watch(hupChan)
 
-	// HTTP/HTTPS Mode - if transport is "http", "HTTP", "https", or "HTTPS"
-	if transport == "http" || transport == "HTTP" || transport == "https" || transport == "HTTPS" {
-		port := cfg.Port
-		if port == "" {
-			log.Fatalf("PORT environment variable is required for HTTP/HTTPS mode. Please set PORT environment variable.")
-		}
+	drainer := shutdown.New()
+	subs := resources.NewSubscriptions(func() time.Duration { return state.Config().Resources.PollInterval })
+	// Methods mcp-go doesn't implement, or can't report api.video errors for,
+	// are answered before messages reach it
+	router := rpc.NewRouter()
+	subs.Register(router)
+	resources.RegisterList(router)
+	completion.Register(router)
+	checker := &health.Checker{Config: state.Config, Client: state.Client, Draining: drainer.Draining, TLS: state.TLSConfig}
 
-		// Determine if HTTPS mode and normalize transport
-		isHTTPS := transport == "https" || transport == "HTTPS"
+	// HTTP/HTTPS Mode
+	if cfg.IsHTTP() {
+		port := cfg.Port
+		isHTTPS := cfg.Transport == "https"
+		transport := "HTTP"
 		if isHTTPS {
 			transport = "HTTPS"
-		} else {
-			transport = "HTTP"
 		}
-		
-		log.Printf("Running in %s mode on port %s", transport, port)
+
+		slog.Info("Running in HTTP server mode", "transport", transport, "port", port)
 
 		mux := http.NewServeMux()
-		mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
-			// Read headers for dynamic config
-			apiCfg := &config.APIConfig{
-				BaseURL:     r.Header.Get("API_BASE_URL"),
-				BearerToken: r.Header.Get("BEARER_TOKEN"),
-				APIKey:      r.Header.Get("API_KEY"),
-				BasicAuth:   r.Header.Get("BASIC_AUTH"),
+		// Only allowed client certificates reach the MCP endpoint, readiness and metrics
+		allowedClients := func() []string { return state.Config().TLS.AllowedClients }
+		var events *webhooks.Sessions
+		if cfg.Webhooks.Enabled {
+			hub := webhooks.NewHub()
+			// Sessions watching a video's status see the change without waiting for the next poll
+			hub.Subscribe(webhooks.Filter{}, func(_ context.Context, event webhooks.Event) {
+				if event.VideoID != "" {
+					subs.Wake(resources.StatusURI(event.VideoID))
+				}
+			})
+			events = webhooks.NewSessions(hub, subs, state.Config)
+			mux.Handle(cfg.Webhooks.Path, logging.HTTPMiddleware(webhooks.NewReceiver(hub, state.Config, state.Client)))
+			slog.Info("Receiving api.video webhooks", "path", cfg.Webhooks.Path)
+		}
+		mux.Handle("/mcp", logging.HTTPMiddleware(drainer.HTTPMiddleware(metrics.InstrumentHandler(auth.Middleware(allowedClients, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Pick up the latest reloaded configuration for each request
+			cfg := state.Config()
+
+			// Expose the verified client certificate, if any, to tool handlers
+			identity, verified := auth.FromRequest(r)
+
+			// Use a server-side credential profile if one is requested and the
+			// client is allowed to, otherwise the credentials in the headers
+			var apiCfg *config.APIConfig
+			if name := r.Header.Get("API_PROFILE"); name != "" {
+				profile, ok := cfg.Profiles[name]
+				if !ok {
+					http.Error(w, "Unknown API_PROFILE", http.StatusBadRequest)
+					return
+				}
+				if !verified || len(profile.AllowedClients) == 0 || !identity.Allowed(profile.AllowedClients) {
+					http.Error(w, "Client certificate not allowed to use API_PROFILE", http.StatusForbidden)
+					return
+				}
+				// The profile's endpoint and credentials can't be overridden
+				for _, header := range credentialHeaders {
+					if r.Header.Get(header) != "" {
+						http.Error(w, "API_PROFILE can't be combined with "+header, http.StatusBadRequest)
+						return
+					}
+				}
+				apiCfg, _ = cfg.ProfileConfig(name)
+			} else {
+				apiCfg = &config.APIConfig{
+					BaseURL:     cmp.Or(r.Header.Get("API_BASE_URL"), cfg.API.BaseURL),
+					BearerToken: r.Header.Get("BEARER_TOKEN"),
+					APIKey:      r.Header.Get("API_KEY"),
+					BasicAuth:   r.Header.Get("BASIC_AUTH"),
+				}
 			}
+			apiCfg.Client = state.Client()
 
 			if apiCfg.BaseURL == "" {
 				http.Error(w, "Missing API_BASE_URL header", http.StatusBadRequest)
 				return
 			}
 
-			log.Printf("Incoming HTTP request - BaseURL: %s", apiCfg.BaseURL)
+			slog.DebugContext(r.Context(), "Incoming MCP request", "base_url", apiCfg.BaseURL)
 
 			// Create MCP server for this request
-			mcpSrv := createMCPServer(apiCfg, transport)
+			mcpSrv := createMCPServer(apiCfg, cfg.Tools, transport, drainer, resources.New(apiCfg, subs, rpc.Owner(apiCfg, identity)), events)
 			handler := server.NewStreamableHTTPServer(mcpSrv, server.WithHTTPContextFunc(
 				func(ctx context.Context, req *http.Request) context.Context {
+					ctx = tracing.Extract(ctx, req)
+					if verified {
+						ctx = auth.WithIdentity(ctx, identity)
+					}
 					return context.WithValue(ctx, "apiConfig", apiCfg)
 				},
 			))
 
-			handler.ServeHTTP(w, r)
-		})
+			router.HandleHTTP(w, r, apiCfg, handler)
+		}))))))
 
+		serveMetrics(cfg.Metrics, mux, checker, allowedClients)
+
+		// Liveness stays open so probes without a client certificate still work
+		mux.HandleFunc("/healthz", health.Liveness)
+		mux.Handle("/readyz", auth.Middleware(allowedClients, checker))
 		mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
 			w.Write([]byte(`{"status":"ok"}`))
 		})
 
 		addr := net.JoinHostPort("0.0.0.0", port)
-		httpServer := &http.Server{Addr: addr, Handler: mux}
+		httpServer := &http.Server{
+			Addr:      addr,
+			Handler:   mux,
+			TLSConfig: &tls.Config{GetConfigForClient: state.GetConfigForClient},
+			// Request contexts end with the drain period, closing idle streams
+			BaseContext: func(net.Listener) context.Context { return drainer.Context() },
+		}
 
 		go func() {
 			// Check if HTTPS mode
 			if isHTTPS {
-				certFile := os.Getenv("CERT_FILE")
-				keyFile := os.Getenv("KEY_FILE")
-				
-				if certFile == "" || keyFile == "" {
-					log.Fatalf("CERT_FILE and KEY_FILE environment variables are required for HTTPS mode")
-				}
-				
-				log.Printf("Starting HTTPS server on %s", addr)
-				if err := httpServer.ListenAndServeTLS(certFile, keyFile); err != http.ErrServerClosed {
-					log.Fatalf("HTTPS server error: %v", err)
+				slog.Info("Starting HTTPS server", "addr", addr)
+				// Certificates and TLS policy come from state so they can be rotated without a restart
+				if err := httpServer.ListenAndServeTLS("", ""); err != http.ErrServerClosed {
+					fatal("HTTPS server error", err)
 				}
 			} else {
-				log.Printf("Starting HTTP server on %s", addr)
+				slog.Info("Starting HTTP server", "addr", addr)
 				if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
-					log.Fatalf("HTTP server error: %v", err)
+					fatal("HTTP server error", err)
 				}
 			}
 		}()
 
 		<-sigChan
-		log.Println("Shutdown signal received")
+		slog.Info("Shutdown signal received, draining")
+		drainer.Drain(state.Config().Shutdown.DrainTimeout, cancelGrace)
+		subs.Close()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), cancelGrace)
 		defer cancel()
 		if err := httpServer.Shutdown(ctx); err != nil {
-			log.Printf("Shutdown error: %v", err)
+			slog.Error("Shutdown error", "error", err)
+			httpServer.Close()
 		} else {
-			log.Println("HTTP server shutdown complete")
+			slog.Info("HTTP server shutdown complete")
 		}
 		return
 	}
 
 	// STDIO Mode - default when no transport or transport is "stdio"
-	log.Println("Running in STDIO mode")
-	mcp := createMCPServer(cfg, "STDIO")
+	slog.Info("Running in STDIO mode")
+	serveMetrics(cfg.Metrics, nil, checker, nil)
+	apiCfg := cfg.APIConfig()
+	apiCfg.Client = state.Client()
+	// The single STDIO client owns its session, its calls have no owner
+	res := resources.New(apiCfg, subs, "")
+	mcp := createMCPServer(apiCfg, cfg.Tools, "STDIO", drainer, res, nil)
+	state.OnReload(func(cfg *config.Config) {
+		// Replace the tool set so new calls use the new credentials and filters
+		apiCfg := cfg.APIConfig()
+		apiCfg.Client = state.Client()
+		mcp.SetTools(serverTools(apiCfg, cfg.Tools, nil)...)
+		res.SetConfig(apiCfg)
+	})
+
+	// Listen rather than ServeStdio, which cancels running calls on the first signal
+	stdio := server.NewStdioServer(mcp)
+	stdio.SetErrorLogger(slog.NewLogLogger(slog.Default().Handler(), slog.LevelError))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stdin, stdout := router.Stdio(ctx, os.Stdin, os.Stdout, stdioSessionID, res.Config)
+	done := make(chan error, 1)
 	go func() {
-		if err := server.ServeStdio(mcp); err != nil {
-			log.Fatalf("STDIO error: %v", err)
+		done <- stdio.Listen(ctx, stdin, stdout)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && !errors.Is(err, context.Canceled) {
+			fatal("STDIO error", err)
+		}
+		slog.Info("STDIO input closed. Exiting STDIO mode.")
+		return
+	case <-sigChan:
+	}
+	slog.Info("Received shutdown signal, draining STDIO mode")
+	drainer.Drain(state.Config().Shutdown.DrainTimeout, cancelGrace)
+	subs.Close()
+	cancel()
+	select {
+	case <-done:
+		slog.Info("STDIO shutdown complete")
+	case <-time.After(cancelGrace):
+		slog.Warn("STDIO server did not stop in time")
+	}
+}
+
+// stdioSessionID is the ID mcp-go gives the single STDIO session.
+const stdioSessionID = "stdio"
+
+// cancelGrace is how long cancelled work gets to return before the server
+// stops waiting for it.
+const cancelGrace = 5 * time.Second
+
+// credentialHeaders are the headers that set the api.video endpoint and
+// credentials of an HTTP request.
+var credentialHeaders = []string{"API_BASE_URL", "BEARER_TOKEN", "API_KEY", "BASIC_AUTH"}
+
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// runPrintConfig prints the resolved configuration to stdout and any
+// validation errors to stderr, returning the process exit code.
+func runPrintConfig(configFile string) int {
+	cfg, err := config.Read(configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	out, err := cfg.Redacted()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	os.Stdout.Write(out)
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// serveMetrics exposes Prometheus metrics on their own listener when an
+// address is configured, otherwise on mux (if any), where only clients
+// matching allowedClients see them. The separate listener also serves the
+// health endpoints, which is how STDIO mode exposes them.
+func serveMetrics(cfg config.MetricsConfig, mux *http.ServeMux, checker *health.Checker, allowedClients func() []string) {
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.Address == "" {
+		if mux != nil {
+			mux.Handle(cfg.Path, auth.Middleware(allowedClients, metrics.Handler()))
+		}
+		return
+	}
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle(cfg.Path, metrics.Handler())
+	metricsMux.HandleFunc("/healthz", health.Liveness)
+	metricsMux.Handle("/readyz", checker)
+	go func() {
+		slog.Info("Serving metrics", "addr", cfg.Address, "path", cfg.Path)
+		if err := http.ListenAndServe(cfg.Address, metricsMux); err != nil {
+			slog.Error("Metrics server error", "error", err)
 		}
 	}()
-	<-sigChan
-	log.Println("Received shutdown signal. Exiting STDIO mode.")
 }
 
-func createMCPServer(cfg *config.APIConfig, mode string) *server.MCPServer {
+func createMCPServer(cfg *config.APIConfig, filter config.ToolsConfig, mode string, drainer *shutdown.Drainer, res *resources.Provider, events *webhooks.Sessions) *server.MCPServer {
+	hooks := &server.Hooks{}
+	metrics.Hooks(hooks)
+
 	mcp := server.NewMCPServer("api.video", "1",
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, false),
+		server.WithPromptCapabilities(false),
+		// Tracing, metrics and logging wrap recovery so recovered panics are recorded as errors
+		server.WithToolHandlerMiddleware(tracing.ToolMiddleware),
+		server.WithToolHandlerMiddleware(metrics.ToolMiddleware),
+		server.WithToolHandlerMiddleware(logging.ToolMiddleware),
+		server.WithToolHandlerMiddleware(drainer.ToolMiddleware),
 		server.WithRecovery(),
+		server.WithHooks(hooks),
 	)
 
-	tools := GetAll(cfg)
-	log.Printf("Loaded %d tools for %s mode", len(tools), mode)
-
-	for _, tool := range tools {
-		mcp.AddTool(tool.Definition, tool.Handler)
-	}
+	tools := serverTools(cfg, filter, events)
+	mcp.AddTools(tools...)
+	mcp.AddPrompts(prompts.GetAll()...)
+	res.Register(mcp, hooks)
+	slog.Debug("Loaded tools", "count", len(tools), "mode", mode)
 
 	return mcp
-}
\ No newline at end of file
+}
+
+// serverTools returns the tools that pass the configured filters, with the
+// event subscription tools when webhook events are received.
+func serverTools(cfg *config.APIConfig, filter config.ToolsConfig, events *webhooks.Sessions) []server.ServerTool {
+	all := GetAll(cfg)
+	if events != nil {
+		all = append(all, events.Tools(cfg)...)
+	}
+	var tools []server.ServerTool
+	for _, tool := range all {
+		if filter.Allows(tool.Definition.Name) {
+			definition := dryrun.Tool(tool.Definition)
+			handler := dryrun.Handler(tool.Handler, filter.DryRun)
+			tools = append(tools, server.ServerTool{Tool: definition, Handler: validate.Handler(definition, handler, filter.UnknownArguments == "reject")})
+		}
+	}
+	return tools
+}
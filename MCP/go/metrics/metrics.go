@@ -0,0 +1,223 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "apivideo_mcp"
+
+// Registry holds every metric exported on /metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	ToolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "Tool calls by tool and result status (ok or error).",
+	}, []string{"tool", "status"})
+
+	ToolErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_errors_total",
+		Help:      "Failed tool calls by tool and api.video problem type.",
+	}, []string{"tool", "problem"})
+
+	ToolDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_call_duration_seconds",
+		Help:      "Tool call duration, including every upstream request it made.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"tool"})
+
+	UpstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_requests_total",
+		Help:      "Requests sent to the api.video API by operation and HTTP status code.",
+	}, []string{"operation", "method", "code"})
+
+	UpstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of single api.video API requests (each retry is observed separately).",
+		Buckets:   []float64{.025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"operation", "method"})
+
+	UpstreamRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_retries_total",
+		Help:      "Retried api.video API requests by operation.",
+	}, []string{"operation"})
+
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Requests throttled by the local limiter (source=local) or answered 429 by api.video (source=upstream).",
+	}, []string{"operation", "source"})
+
+	UploadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upload_bytes_total",
+		Help:      "Bytes uploaded to api.video in multipart requests.",
+	}, []string{"operation"})
+
+	CacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Cacheable api.video requests by result: hit (served from the cache), revalidated (304 Not Modified) or miss.",
+	}, []string{"operation", "result"})
+
+	WebhookEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_events_total",
+		Help:      "api.video webhook deliveries by event type and result: dispatched or duplicate.",
+	}, []string{"type", "result"})
+
+	WebhookRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_rejections_total",
+		Help:      "api.video webhook deliveries refused by reason, such as bad_signature or stale.",
+	}, []string{"reason"})
+
+	ActiveSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_sessions",
+		Help:      "MCP sessions currently registered (STDIO and HTTP streams).",
+	})
+
+	HTTPInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Requests to the /mcp endpoint currently being served.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		ToolCalls, ToolErrors, ToolDuration,
+		UpstreamRequests, UpstreamDuration, UpstreamRetries, RateLimited, UploadBytes, CacheLookups,
+		WebhookEvents, WebhookRejections,
+		ActiveSessions, HTTPInFlight,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// InstrumentHandler tracks the requests in flight on an MCP endpoint.
+func InstrumentHandler(next http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerInFlight(HTTPInFlight, next)
+}
+
+type operationKey struct{}
+
+// WithOperation names the api.video operation that requests made with ctx
+// belong to. Tool calls use the tool name.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// Operation returns the operation name stored in ctx, or "unknown".
+func Operation(ctx context.Context) string {
+	if op, ok := ctx.Value(operationKey{}).(string); ok {
+		return op
+	}
+	return "unknown"
+}
+
+// ToolMiddleware records call counts, errors and durations for every tool and
+// tags outbound requests with the tool name.
+func ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tool := request.Params.Name
+		start := time.Now()
+		result, err := next(WithOperation(ctx, tool), request)
+		ToolDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+
+		switch {
+		case err != nil:
+			ToolCalls.WithLabelValues(tool, "error").Inc()
+			ToolErrors.WithLabelValues(tool, "internal").Inc()
+		case result != nil && result.IsError:
+			ToolCalls.WithLabelValues(tool, "error").Inc()
+			ToolErrors.WithLabelValues(tool, problemFromResult(result)).Inc()
+		default:
+			ToolCalls.WithLabelValues(tool, "ok").Inc()
+		}
+		return result, err
+	}
+}
+
+// Hooks keeps the active session gauge up to date.
+func Hooks(hooks *server.Hooks) {
+	hooks.AddOnRegisterSession(func(context.Context, server.ClientSession) { ActiveSessions.Inc() })
+	hooks.AddOnUnregisterSession(func(context.Context, server.ClientSession) { ActiveSessions.Dec() })
+}
+
+// problemFromResult extracts the api.video problem type from an error result
+// of the form "API error: {problem json}".
+func problemFromResult(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		if body, ok := strings.CutPrefix(text.Text, "API error: "); ok {
+			return problemType([]byte(body))
+		}
+	}
+	return "tool_error"
+}
+
+// problemType returns the last path segment of an RFC 7807 problem type such
+// as "https://docs.api.video/reference/resource-not-found".
+func problemType(body []byte) string {
+	var problem struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(body, &problem) == nil && problem.Type != "" {
+		return path.Base(problem.Type)
+	}
+	return "unknown"
+}
+
+// Transport records latency, status codes and upload sizes of the requests
+// it sends through Next.
+type Transport struct {
+	Next http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op := Operation(req.Context())
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") && req.ContentLength > 0 {
+		UploadBytes.WithLabelValues(op).Add(float64(req.ContentLength))
+	}
+
+	start := time.Now()
+	resp, err := t.Next.RoundTrip(req)
+	UpstreamDuration.WithLabelValues(op, req.Method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		UpstreamRequests.WithLabelValues(op, req.Method, "error").Inc()
+		return resp, err
+	}
+	UpstreamRequests.WithLabelValues(op, req.Method, strconv.Itoa(resp.StatusCode)).Inc()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		RateLimited.WithLabelValues(op, "upstream").Inc()
+	}
+	return resp, nil
+}
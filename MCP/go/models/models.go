@@ -549,6 +549,7 @@ type Webhook struct {
 	Url string `json:"url,omitempty"` // URL of the webhook
 	Webhookid string `json:"webhookId,omitempty"` // Unique identifier of the webhook
 	Createdat string `json:"createdAt,omitempty"` // When an webhook was created, presented in ISO-8601 format.
+	Signaturesecret string `json:"signatureSecret,omitempty"` // Secret used to sign the deliveries of the webhook.
 }
 
 // VideoSource represents the VideoSource schema from the OpenAPI specification
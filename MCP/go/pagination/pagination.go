@@ -0,0 +1,239 @@
+package pagination
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// maxItems caps the items one call returns with limit or fetchAll.
+	maxItems = 1000
+	// maxPageSize is the largest page api.video serves.
+	maxPageSize = 100
+)
+
+// WithCursor adds the cursor, limit and fetchAll arguments of List to a list
+// tool.
+func WithCursor() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("cursor", mcp.Description("The nextCursor returned by a previous call, to continue the list where it stopped. Repeat the same filters."))(tool)
+		mcp.WithNumber("limit", mcp.Min(1), mcp.Description(fmt.Sprintf("Fetch pages until this many items are returned, at most %d. Without limit or fetchAll a single page is returned.", maxItems)))(tool)
+		mcp.WithBoolean("fetchAll", mcp.Description(fmt.Sprintf("Fetch every page, up to %d items. A nextCursor in the result means more items remain.", maxItems)))(tool)
+	}
+}
+
+// Args are the arguments WithCursor adds, to embed in the arguments of list
+// tools.
+type Args struct {
+	Cursor   string `arg:"cursor"`
+	Limit    int    `arg:"limit"`
+	FetchAll bool   `arg:"fetchAll"`
+}
+
+// cursor is the decoded form of a nextCursor: the page to fetch, as the
+// pagination link api.video returned for it, and how many of its items were
+// already returned.
+type cursor struct {
+	URI  string `json:"uri"`
+	Skip int    `json:"skip,omitempty"`
+}
+
+func (c cursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// page is one response of a list endpoint.
+type page struct {
+	Data       []json.RawMessage `json:"data"`
+	Pagination json.RawMessage   `json:"pagination"`
+}
+
+// next returns the link to the following page, if any.
+func (p page) next() string {
+	var pagination models.Pagination
+	if json.Unmarshal(p.Pagination, &pagination) != nil {
+		return ""
+	}
+	for _, link := range pagination.Links {
+		if link.Rel == "next" {
+			return link.Uri
+		}
+	}
+	return ""
+}
+
+// List fetches the list at rawURL, built by a list tool from its filters,
+// and returns its items with the pagination of the last page read and a
+// nextCursor when more items remain. The cursor, limit and fetchAll
+// arguments select where the list starts and how many pages are read.
+func List(ctx context.Context, cfg *config.APIConfig, rawURL string, args Args) (*mcp.CallToolResult, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
+	}
+
+	skip := 0
+	if args.Cursor != "" {
+		c, err := decode(args.Cursor, u.Path, basePath(cfg))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := follow(u, c.URI); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		skip = c.Skip
+	}
+
+	target := 0
+	if args.FetchAll {
+		target = maxItems
+	}
+	if args.Limit < 0 {
+		return mcp.NewToolResultError("Invalid parameter: limit must be at least 1"), nil
+	}
+	if args.Limit > 0 {
+		target = min(args.Limit, maxItems)
+	}
+	if q := u.Query(); target > 0 && q.Get("pageSize") == "" {
+		// Read as few pages as possible; a cursor always carries its page size
+		q.Set("pageSize", strconv.Itoa(min(target, maxPageSize)))
+		u.RawQuery = q.Encode()
+	}
+
+	var items []json.RawMessage
+	var last page
+	nextCursor := ""
+	for {
+		body, result := fetch(ctx, cfg, u.String())
+		if result != nil {
+			return result, nil
+		}
+		// Decode each page afresh, items keep pointing into the previous ones
+		last = page{}
+		if err := json.Unmarshal(body, &last); err != nil || last.Data == nil {
+			// Not a paginated list, return it as the tools do
+			return mcp.NewToolResultText(string(body)), nil
+		}
+
+		data := last.Data[min(skip, len(last.Data)):]
+		next := last.next()
+		nextCursor = ""
+		if target > 0 && len(items)+len(data) > target {
+			// Stop within the page, the cursor resumes after the last item returned
+			taken := target - len(items)
+			items = append(items, data[:taken]...)
+			nextCursor = cursor{URI: u.RequestURI(), Skip: skip + taken}.encode()
+			break
+		}
+		items = append(items, data...)
+		skip = 0
+		if next != "" {
+			nextCursor = cursor{URI: next}.encode()
+		}
+		if target == 0 || len(items) >= target || next == "" {
+			break
+		}
+		if err := follow(u, next); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	out := map[string]any{"data": items, "pagination": last.Pagination}
+	if items == nil {
+		out["data"] = []json.RawMessage{}
+	}
+	if nextCursor != "" {
+		out["nextCursor"] = nextCursor
+	}
+	prettyJSON, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(prettyJSON)), nil
+}
+
+// basePath returns the path of the configured API URL, which the list paths
+// start with, without its trailing slash.
+func basePath(cfg *config.APIConfig) string {
+	base, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(base.Path, "/")
+}
+
+// decode parses a cursor and checks it belongs to the list at path. Cursors
+// made from api.video's pagination links have paths relative to the API URL,
+// whose path is base.
+func decode(value, path, base string) (cursor, error) {
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	if err != nil || c.Skip < 0 {
+		return c, fmt.Errorf("Invalid cursor: pass the nextCursor returned by this tool")
+	}
+	link, err := url.Parse(c.URI)
+	if err != nil || (link.Path != path && base+link.Path != path) {
+		return c, fmt.Errorf("Invalid cursor: it was returned for another list")
+	}
+	return c, nil
+}
+
+// follow points u to the page of a pagination link. Only the query of the
+// link is used, so requests stay on the configured API. Filters missing from
+// the link are kept.
+func follow(u *url.URL, link string) error {
+	l, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("Invalid pagination link %q", link)
+	}
+	q := u.Query()
+	for key, values := range l.Query() {
+		q[key] = values
+	}
+	u.RawQuery = q.Encode()
+	return nil
+}
+
+// fetch sends one list request, returning the body or an error result.
+func fetch(ctx context.Context, cfg *config.APIConfig, url string) ([]byte, *mcp.CallToolResult) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, mcp.NewToolResultErrorFromErr("Failed to create request", err)
+	}
+	// Set authentication based on auth type
+	if cfg.BearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cfg.HTTPClient().Do(req)
+	if err != nil {
+		return nil, mcp.NewToolResultErrorFromErr("Request failed", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, mcp.NewToolResultErrorFromErr("Failed to read response body", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("API error: %s", body))
+	}
+	return body, nil
+}
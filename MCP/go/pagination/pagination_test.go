@@ -0,0 +1,166 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/tools"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor cursor
+		path   string
+		base   string
+	}{
+		{"relative link", cursor{URI: "/videos?currentPage=2&pageSize=25"}, "/videos", ""},
+		{"with skip", cursor{URI: "/videos?currentPage=2", Skip: 7}, "/videos", ""},
+		{"API URL with a path", cursor{URI: "/players?currentPage=3"}, "/v1/players", "/v1"},
+		{"link with the API path", cursor{URI: "/v1/players?currentPage=3"}, "/v1/players", "/v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decode(tt.cursor.encode(), tt.path, tt.base)
+			if err != nil || got != tt.cursor {
+				t.Errorf("decode = %+v, %v, want %+v, nil", got, err, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		path  string
+		base  string
+	}{
+		{"not base64", "not a cursor!", "/videos", ""},
+		{"not JSON", "bm90IGpzb24", "/videos", ""},
+		{"negative skip", cursor{URI: "/videos", Skip: -1}.encode(), "/videos", ""},
+		{"another list", cursor{URI: "/players?currentPage=2"}.encode(), "/videos", ""},
+		{"a sub-resource", cursor{URI: "/videos/vi1/captions?currentPage=2"}.encode(), "/videos", ""},
+		{"a prefix of the path", cursor{URI: "/live?currentPage=2"}.encode(), "/live-streams", ""},
+		{"a path outside the API URL", cursor{URI: "/other/videos?currentPage=2"}.encode(), "/v1/videos", "/v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := decode(tt.value, tt.path, tt.base); err == nil {
+				t.Errorf("decode = %+v, want an error", c)
+			}
+		})
+	}
+}
+
+// listServer serves /items with total items, pageSize to a page, linking
+// each page to the next one.
+func listServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/items" {
+			http.NotFound(w, r)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("currentPage"))
+		page = max(page, 1)
+		size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		if size == 0 {
+			size = 3
+		}
+		var data []string
+		for i := (page - 1) * size; i < min(page*size, total); i++ {
+			data = append(data, fmt.Sprint("item", i))
+		}
+		links := []map[string]string{}
+		if page*size < total {
+			links = append(links, map[string]string{"rel": "next", "uri": fmt.Sprintf("/items?currentPage=%d&pageSize=%d", page+1, size)})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data, "pagination": map[string]any{"currentPage": page, "links": links}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// list calls List and decodes the items and nextCursor of its result.
+func list(t *testing.T, cfg *config.APIConfig, args Args) ([]string, string, error) {
+	t.Helper()
+	result, err := List(context.Background(), cfg, cfg.BaseURL+"/items", args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := tools.ResultText(result)
+	if result.IsError {
+		return nil, "", fmt.Errorf("%s", text)
+	}
+	var out struct {
+		Data       []string `json:"data"`
+		NextCursor string   `json:"nextCursor"`
+	}
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatalf("result %q: %v", text, err)
+	}
+	return out.Data, out.NextCursor, nil
+}
+
+func TestList(t *testing.T) {
+	cfg := &config.APIConfig{BaseURL: listServer(t, 8).URL}
+	tests := []struct {
+		name       string
+		args       Args
+		wantItems  int
+		wantCursor bool
+	}{
+		{"one page", Args{}, 3, true},
+		{"limit within a page", Args{Limit: 2}, 2, true},
+		{"limit across pages", Args{Limit: 5}, 5, true},
+		{"limit past the end", Args{Limit: 20}, 8, false},
+		{"fetchAll", Args{FetchAll: true}, 8, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, next, err := list(t, cfg, tt.args)
+			if err != nil || len(items) != tt.wantItems || (next != "") != tt.wantCursor {
+				t.Errorf("List = %d items, cursor %q, %v, want %d items, cursor = %v", len(items), next, err, tt.wantItems, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestListResumes(t *testing.T) {
+	cfg := &config.APIConfig{BaseURL: listServer(t, 8).URL}
+	// Following the cursors returns every item once, in order
+	var all []string
+	args := Args{Limit: 3}
+	for range 10 {
+		items, next, err := list(t, cfg, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, items...)
+		if next == "" {
+			break
+		}
+		args = Args{Cursor: next, Limit: 3}
+	}
+	if len(all) != 8 {
+		t.Fatalf("got %d items, want 8: %v", len(all), all)
+	}
+	for i, item := range all {
+		if want := fmt.Sprint("item", i); item != want {
+			t.Errorf("item %d = %q, want %q", i, item, want)
+		}
+	}
+
+	// A cursor of another list is refused before anything is fetched
+	foreign := cursor{URI: "/other?currentPage=2"}.encode()
+	if _, _, err := list(t, cfg, Args{Cursor: foreign}); err == nil {
+		t.Error("List accepted a cursor of another list")
+	}
+}
@@ -0,0 +1,46 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func Brand_playerHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	color, err := required(request, "brandColor")
+	if err != nil {
+		return nil, err
+	}
+
+	step := "1. Create a player with `post_players`"
+	if playerID := argument(request, "playerId", ""); playerID != "" {
+		step = fmt.Sprintf("1. Update player %q with `patch_players_playerId`", playerID)
+	}
+	lines := []string{
+		fmt.Sprintf("Brand an api.video player with the color %s.", color),
+		"",
+		step + ". Player colors are RGBA strings such as `rgba(88, 131, 255, .95)`; convert the brand color first. Use it for `link`, `trackPlayed` and `backgroundText`, a lighter variant for `linkHover`, and keep text readable against `backgroundTop` and `backgroundBottom`.",
+		"2. Show the colors you chose and the playerId.",
+	}
+	if videoID := argument(request, "videoId", ""); videoID != "" {
+		lines = append(lines,
+			fmt.Sprintf("3. Attach the player to video %q with `patch_videos_videoId` (playerId) and give me the player URL from `assets.player`.", videoID))
+	}
+	return userPrompt("Brand a player", lines...), nil
+}
+
+func CreateBrand_playerPrompt() server.ServerPrompt {
+	prompt := mcp.NewPrompt("brand_player",
+		mcp.WithPromptDescription("Create or update a player theme in the brand color and optionally attach it to a video"),
+		mcp.WithArgument("brandColor", mcp.RequiredArgument(), mcp.ArgumentDescription("Brand color, as hex, rgb or a color name")),
+		mcp.WithArgument("playerId", mcp.ArgumentDescription("Existing player to update instead of creating one")),
+		mcp.WithArgument("videoId", mcp.ArgumentDescription("Video to attach the player to")),
+	)
+
+	return server.ServerPrompt{
+		Prompt:  prompt,
+		Handler: Brand_playerHandler,
+	}
+}
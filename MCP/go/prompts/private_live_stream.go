@@ -0,0 +1,44 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func Private_live_streamHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	name, err := required(request, "name")
+	if err != nil {
+		return nil, err
+	}
+	record := argument(request, "record", "false")
+
+	create := fmt.Sprintf("1. Create the stream with `post_live-streams`: name %q, public false, record %s", name, record)
+	if playerID := argument(request, "playerId", ""); playerID != "" {
+		create += fmt.Sprintf(", playerId %q", playerID)
+	}
+	return userPrompt("Set up a private live stream",
+		fmt.Sprintf("Set up a private live stream called %q on api.video.", name),
+		"",
+		create+".",
+		"2. From the response, give me the liveStreamId, the RTMP ingest URL `rtmp://broadcast.api.video/s` and the streamKey. Remind me to keep the stream key secret.",
+		"3. Explain that private streams need a private token for each viewer: every `get_live-streams_liveStreamId` call returns asset URLs with a fresh token, and those URLs are the ones to share.",
+		"4. Once I say I'm live, confirm with `get_live-streams_liveStreamId` that `broadcasting` is true.",
+	), nil
+}
+
+func CreatePrivate_live_streamPrompt() server.ServerPrompt {
+	prompt := mcp.NewPrompt("private_live_stream",
+		mcp.WithPromptDescription("Create a private live stream and explain how to broadcast and share it"),
+		mcp.WithArgument("name", mcp.RequiredArgument(), mcp.ArgumentDescription("Name of the live stream")),
+		mcp.WithArgument("record", mcp.ArgumentDescription("true to record the stream as a video, default false")),
+		mcp.WithArgument("playerId", mcp.ArgumentDescription("Player theme to attach to the stream")),
+	)
+
+	return server.ServerPrompt{
+		Prompt:  prompt,
+		Handler: Private_live_streamHandler,
+	}
+}
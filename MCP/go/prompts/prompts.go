@@ -0,0 +1,44 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetAll returns the prompts published next to the tools. Each one walks the
+// model through a common api.video workflow and names the tools to call.
+func GetAll() []server.ServerPrompt {
+	return []server.ServerPrompt{
+		CreatePublish_videoPrompt(),
+		CreatePrivate_live_streamPrompt(),
+		CreateBrand_playerPrompt(),
+		CreateSummarize_video_analyticsPrompt(),
+	}
+}
+
+// argument returns a prompt argument, or def when it is missing or empty.
+func argument(request mcp.GetPromptRequest, name, def string) string {
+	if val := strings.TrimSpace(request.Params.Arguments[name]); val != "" {
+		return val
+	}
+	return def
+}
+
+// required returns a required prompt argument.
+func required(request mcp.GetPromptRequest, name string) (string, error) {
+	val := argument(request, name, "")
+	if val == "" {
+		return "", fmt.Errorf("missing required argument: %s", name)
+	}
+	return val, nil
+}
+
+// userPrompt wraps the instructions in a single user message.
+func userPrompt(description string, lines ...string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(strings.Join(lines, "\n"))),
+	})
+}
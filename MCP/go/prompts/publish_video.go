@@ -0,0 +1,51 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func Publish_videoHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	title, err := required(request, "title")
+	if err != nil {
+		return nil, err
+	}
+	source, err := required(request, "sourceUrl")
+	if err != nil {
+		return nil, err
+	}
+	language := argument(request, "language", "en")
+
+	create := fmt.Sprintf("1. Create the video with `post_videos`, using title %q and source %q", title, source)
+	if playerID := argument(request, "playerId", ""); playerID != "" {
+		create += fmt.Sprintf(" and playerId %q", playerID)
+	}
+	return userPrompt("Publish a video with captions",
+		fmt.Sprintf("Publish the video at %s on api.video with %s captions.", source, language),
+		"",
+		create+". Note the returned videoId.",
+		"2. Wait until it is playable: subscribe to the resource `apivideo://videos/{videoId}/status` if you can, otherwise call `get_videos_videoId_status` every few seconds. Stop on a failed quality and report it.",
+		fmt.Sprintf("3. Call `get_videos_videoId_captions` and look for a caption with srclang %q. If there is none, upload it with `post_videos_videoId_captions_language`, with content in WebVTT format starting with WEBVTT; ask me for the captions if you don't have them.", language),
+		fmt.Sprintf("4. Make it the default caption with `patch_videos_videoId_captions_language` (language %q, default true).", language),
+		fmt.Sprintf("5. Check the captions by reading `apivideo://videos/{videoId}/captions/%s/text` and quote the first lines.", language),
+		"6. Finish with the videoId, the player URL from `assets.player` and anything that went wrong.",
+	), nil
+}
+
+func CreatePublish_videoPrompt() server.ServerPrompt {
+	prompt := mcp.NewPrompt("publish_video",
+		mcp.WithPromptDescription("Publish a video from a URL, wait for encoding and set its default captions"),
+		mcp.WithArgument("title", mcp.RequiredArgument(), mcp.ArgumentDescription("Title of the new video")),
+		mcp.WithArgument("sourceUrl", mcp.RequiredArgument(), mcp.ArgumentDescription("Public URL of the video file to import")),
+		mcp.WithArgument("language", mcp.ArgumentDescription("BCP 47 language of the captions, default en")),
+		mcp.WithArgument("playerId", mcp.ArgumentDescription("Player theme to attach to the video")),
+	)
+
+	return server.ServerPrompt{
+		Prompt:  prompt,
+		Handler: Publish_videoHandler,
+	}
+}
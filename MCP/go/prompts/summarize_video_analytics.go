@@ -0,0 +1,43 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func Summarize_video_analyticsHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	videoID, err := required(request, "videoId")
+	if err != nil {
+		return nil, err
+	}
+	period := argument(request, "period", time.Now().UTC().Format("2006-01"))
+	language := argument(request, "language", "en")
+
+	return userPrompt("Summarise analytics for a video",
+		fmt.Sprintf("Summarise how video %q performed during %s.", videoID, period),
+		"",
+		fmt.Sprintf("1. Get the title and duration with `get_videos_videoId` (videoId %q).", videoID),
+		fmt.Sprintf("2. List the player sessions with `get_analytics_videos_videoId` (period %q, pageSize 100) and follow the pagination until the last page.", period),
+		"3. If a few sessions look unusual, inspect them with `get_analytics_sessions_sessionId_events`; don't fetch events for every session.",
+		"4. Report the number of sessions, their trend over the period, devices, operating systems, browsers and countries, and how far viewers watched when the events show it.",
+		fmt.Sprintf("Write the summary in the language %q and keep it under 200 words. Say so plainly if there were no sessions.", language),
+	), nil
+}
+
+func CreateSummarize_video_analyticsPrompt() server.ServerPrompt {
+	prompt := mcp.NewPrompt("summarize_video_analytics",
+		mcp.WithPromptDescription("Summarise the player sessions of a video over a period"),
+		mcp.WithArgument("videoId", mcp.RequiredArgument(), mcp.ArgumentDescription("Video to report on")),
+		mcp.WithArgument("period", mcp.ArgumentDescription("Day (2024-01-01), week (2024-W01), month (2024-01), year (2024) or range (2024-01-01/2024-01-15), default the current month")),
+		mcp.WithArgument("language", mcp.ArgumentDescription("BCP 47 language to write the summary in, default en")),
+	)
+
+	return server.ServerPrompt{
+		Prompt:  prompt,
+		Handler: Summarize_video_analyticsHandler,
+	}
+}
@@ -13,6 +13,7 @@ import (
 	tools_authentication "github.com/api-video/mcp-server/tools/authentication"
 	tools_videos_delegated_upload "github.com/api-video/mcp-server/tools/videos_delegated_upload"
 	tools_account "github.com/api-video/mcp-server/tools/account"
+	tools_workflows "github.com/api-video/mcp-server/tools/workflows"
 )
 
 func GetAll(cfg *config.APIConfig) []models.Tool {
@@ -24,6 +25,11 @@ func GetAll(cfg *config.APIConfig) []models.Tool {
 		tools_chapters.CreateDelete_videos_videoid_chapters_languageTool(cfg),
 		tools_chapters.CreateGet_videos_videoid_chapters_languageTool(cfg),
 		tools_videos.CreateGet_video_statusTool(cfg),
+		tools_videos.CreateWait_for_videoTool(cfg),
+		tools_workflows.CreatePublish_videoTool(cfg),
+		tools_videos.CreateBulk_update_videosTool(cfg),
+		tools_videos.CreateBulk_tag_videosTool(cfg),
+		tools_videos.CreateBulk_delete_videosTool(cfg),
 		tools_players.CreateDelete_players_playerid_logoTool(cfg),
 		tools_analytics.CreateGet_analytics_live_streams_livestreamidTool(cfg),
 		tools_analytics.CreateGet_analytics_sessions_sessionid_eventsTool(cfg),
@@ -38,6 +44,7 @@ func GetAll(cfg *config.APIConfig) []models.Tool {
 		tools_captions.CreateDelete_videos_videoid_captions_languageTool(cfg),
 		tools_captions.CreateGet_videos_videoid_captions_languageTool(cfg),
 		tools_captions.CreatePatch_videos_videoid_captions_languageTool(cfg),
+		tools_captions.CreatePost_videos_videoid_captions_languageTool(cfg),
 		tools_videos_delegated_upload.CreateGet_upload_tokensTool(cfg),
 		tools_videos_delegated_upload.CreatePost_upload_tokensTool(cfg),
 		tools_live.CreatePatch_live_streams_livestreamidTool(cfg),
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/httpclient"
+	"github.com/api-video/mcp-server/logging"
+)
+
+// serverState holds the configuration currently in effect. Reloads swap it
+// atomically, so requests and tool calls already running keep the values they
+// started with while new ones pick up the changes.
+type serverState struct {
+	file   string
+	cfg    atomic.Pointer[config.Config]
+	client atomic.Pointer[http.Client]
+	tls    atomic.Pointer[tls.Config]
+
+	mu       sync.Mutex // serializes reloads
+	onReload []func(*config.Config)
+}
+
+func newServerState(file string) (*serverState, error) {
+	cfg, err := config.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	s := &serverState{file: file}
+	if err := s.apply(cfg); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Config returns the configuration currently in effect.
+func (s *serverState) Config() *config.Config {
+	return s.cfg.Load()
+}
+
+// Client returns the HTTP client built from the current configuration.
+func (s *serverState) Client() *http.Client {
+	return s.client.Load()
+}
+
+// OnReload registers fn to run after each successful reload.
+func (s *serverState) OnReload(fn func(*config.Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onReload = append(s.onReload, fn)
+}
+
+// GetConfigForClient serves the most recently loaded certificate, client CAs
+// and TLS policy in HTTPS mode.
+func (s *serverState) GetConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return s.tls.Load(), nil
+}
+
+// TLSConfig returns the certificate, client CAs and TLS policy served in HTTPS
+// mode, nil in other modes.
+func (s *serverState) TLSConfig() *tls.Config {
+	return s.tls.Load()
+}
+
+// Reload re-reads the configuration file and certificates. If anything is
+// invalid the previous configuration stays in effect.
+func (s *serverState) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, err := config.Load(s.file)
+	if err != nil {
+		return err
+	}
+	old := s.Config()
+	if cfg.Transport != old.Transport || cfg.Port != old.Port {
+		slog.Warn("Config reload: transport and port changes need a restart", "transport", old.Transport, "port", old.Port)
+		cfg.Transport, cfg.Port = old.Transport, old.Port
+	}
+	// The receiver route is registered at startup, and deliveries must keep
+	// being checked against the settings it was registered with
+	if cfg.Webhooks.Enabled != old.Webhooks.Enabled || cfg.Webhooks.Path != old.Webhooks.Path || cfg.Webhooks.MaxSkew != old.Webhooks.MaxSkew {
+		slog.Warn("Config reload: webhooks.enabled, webhooks.path and webhooks.maxSkew changes need a restart",
+			"enabled", old.Webhooks.Enabled, "path", old.Webhooks.Path, "max_skew", old.Webhooks.MaxSkew)
+		cfg.Webhooks.Enabled, cfg.Webhooks.Path, cfg.Webhooks.MaxSkew = old.Webhooks.Enabled, old.Webhooks.Path, old.Webhooks.MaxSkew
+	}
+	// The kept values may need settings the new file doesn't validate, such
+	// as the webhooks profile of a receiver the file disables
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := s.apply(cfg); err != nil {
+		return err
+	}
+	for _, fn := range s.onReload {
+		fn(cfg)
+	}
+	return nil
+}
+
+func (s *serverState) apply(cfg *config.Config) error {
+	if cfg.Transport == "https" {
+		tlsCfg, err := cfg.TLS.ServerConfig()
+		if err != nil {
+			return err
+		}
+		s.tls.Store(tlsCfg)
+	}
+	// A new client starts with an empty cache and a full rate limit bucket, so
+	// it is only built when its settings change
+	if old := s.Config(); old == nil || !reflect.DeepEqual(old.HTTP, cfg.HTTP) {
+		s.client.Store(httpclient.New(cfg.HTTP))
+	}
+	logging.Apply(cfg.Logging)
+	s.cfg.Store(cfg)
+	return nil
+}
+
+// watch reloads on every value received from hup and whenever the config
+// file, the TLS certificate files or the client CA bundles change on disk.
+func (s *serverState) watch(hup <-chan os.Signal) {
+	modTimes := s.modTimes()
+	timer := time.NewTimer(s.pollInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-hup:
+			slog.Info("SIGHUP received, reloading configuration")
+		case <-timer.C:
+			current := s.modTimes()
+			changed := len(current) != len(modTimes)
+			for file, mod := range current {
+				changed = changed || !mod.Equal(modTimes[file])
+			}
+			modTimes = current
+			timer.Reset(s.pollInterval())
+			if !changed {
+				continue
+			}
+			slog.Info("Configuration files changed, reloading configuration")
+		}
+		if err := s.Reload(); err != nil {
+			slog.Error("Config reload failed, keeping previous configuration", "error", err)
+			continue
+		}
+		modTimes = s.modTimes()
+		slog.Info("Configuration reloaded")
+	}
+}
+
+// pollInterval returns how long to wait before checking files again. When
+// file watching is disabled the timer still runs, rarely, to notice when a
+// reload re-enables it.
+func (s *serverState) pollInterval() time.Duration {
+	if interval := s.Config().Reload.Interval; interval > 0 {
+		return interval
+	}
+	return time.Minute
+}
+
+// modTimes returns the modification times of the files a reload reads, or
+// nil when file watching is disabled.
+func (s *serverState) modTimes() map[string]time.Time {
+	cfg := s.Config()
+	if cfg.Reload.Interval == 0 {
+		return nil
+	}
+	files := []string{s.file}
+	if s.file == "" {
+		files[0] = os.Getenv("CONFIG_FILE")
+	}
+	if cfg.Transport == "https" {
+		files = append(files, cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		files = append(files, cfg.TLS.ClientCAFiles...)
+	}
+	times := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		// os.Stat follows symlinks, so atomic swaps of mounted secrets count.
+		if info, err := os.Stat(file); err == nil {
+			times[file] = info.ModTime()
+		}
+	}
+	return times
+}
@@ -0,0 +1,315 @@
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/rpc"
+	"github.com/api-video/mcp-server/tools"
+	tools_captions "github.com/api-video/mcp-server/tools/captions"
+	tools_chapters "github.com/api-video/mcp-server/tools/chapters"
+	tools_live "github.com/api-video/mcp-server/tools/live"
+	tools_players "github.com/api-video/mcp-server/tools/players"
+	tools_videos "github.com/api-video/mcp-server/tools/videos"
+	tools_webhooks "github.com/api-video/mcp-server/tools/webhooks"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listPageSize is how many objects one resources/list page holds.
+const listPageSize = 50
+
+// Provider exposes api.video objects as MCP resources under apivideo:// URIs.
+// Reads are served by the same handlers as the matching GET tools.
+type Provider struct {
+	cfg   atomic.Pointer[config.APIConfig]
+	subs  *Subscriptions
+	owner string
+}
+
+// New returns a Provider calling api.video with cfg for the caller owner, see
+// rpc.Owner. Subscriptions are shared by every Provider in the process.
+func New(cfg *config.APIConfig, subs *Subscriptions, owner string) *Provider {
+	p := &Provider{subs: subs, owner: owner}
+	p.cfg.Store(cfg)
+	return p
+}
+
+// Config returns the credentials in use.
+func (p *Provider) Config() *config.APIConfig {
+	return p.cfg.Load()
+}
+
+// SetConfig replaces the credentials used by later reads, after a reload.
+func (p *Provider) SetConfig(cfg *config.APIConfig) {
+	p.cfg.Store(cfg)
+}
+
+// Register adds the resource templates to mcpSrv and the hooks delivering
+// subscription notifications.
+func (p *Provider) Register(mcpSrv *server.MCPServer, hooks *server.Hooks) {
+	mcpSrv.AddResourceTemplates(p.Templates()...)
+	p.subs.track(mcpSrv, p.owner, hooks)
+}
+
+// RegisterList serves resources/list on router, so a failed api.video
+// request is reported to the client as an error rather than an empty page.
+func RegisterList(router *rpc.Router) {
+	router.Handle("resources/list", listResources)
+}
+
+// Templates returns the resource templates to register on the MCP server.
+func (p *Provider) Templates() []server.ServerResourceTemplate {
+	return []server.ServerResourceTemplate{
+		{
+			Template: mcp.NewResourceTemplate("apivideo://videos/{videoId}", "video",
+				mcp.WithTemplateDescription("A video object: title, tags, metadata, source and asset URLs"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: p.fromTool("video", tools_videos.Get_videoHandler),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://videos/{videoId}/status", "video-status",
+				mcp.WithTemplateDescription("Ingest and encoding status of a video. Subscribe to be notified when it changes"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: p.fromTool("video-status", tools_videos.Get_video_statusHandler),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://live-streams/{liveStreamId}", "live-stream",
+				mcp.WithTemplateDescription("A live stream object, including whether it is broadcasting"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: p.fromTool("live-stream", tools_live.Get_live_streams_livestreamidHandler),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://players/{playerId}", "player",
+				mcp.WithTemplateDescription("A player theme: colors, controls and logo"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: p.fromTool("player", tools_players.Get_players_playeridHandler),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://webhooks/{webhookId}", "webhook",
+				mcp.WithTemplateDescription("A webhook: its URL and the events it is sent for"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: p.fromTool("webhook", tools_webhooks.Get_webhookHandler),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://videos/{videoId}/captions/{language}", "caption",
+				mcp.WithTemplateDescription("The caption of a video in one language"),
+				mcp.WithTemplateMIMEType("application/json"),
+			),
+			Handler: p.fromTool("caption", tools_captions.Get_videos_videoid_captions_languageHandler),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://videos/{videoId}/captions/{language}/vtt", "caption-vtt",
+				mcp.WithTemplateDescription("The WebVTT caption file of a video in one language"),
+				mcp.WithTemplateMIMEType("text/vtt"),
+			),
+			Handler: p.vttFile("caption-vtt", tools_captions.Get_videos_videoid_captions_languageHandler, false),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://videos/{videoId}/captions/{language}/text", "caption-text",
+				mcp.WithTemplateDescription("Plain text transcript of a video built from its captions in one language"),
+				mcp.WithTemplateMIMEType("text/plain"),
+			),
+			Handler: p.vttFile("caption-text", tools_captions.Get_videos_videoid_captions_languageHandler, true),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://videos/{videoId}/chapters/{language}/vtt", "chapters-vtt",
+				mcp.WithTemplateDescription("The WebVTT chapters file of a video in one language"),
+				mcp.WithTemplateMIMEType("text/vtt"),
+			),
+			Handler: p.vttFile("chapters-vtt", tools_chapters.Get_videos_videoid_chapters_languageHandler, false),
+		},
+		{
+			Template: mcp.NewResourceTemplate("apivideo://videos/{videoId}/chapters/{language}/text", "chapters-text",
+				mcp.WithTemplateDescription("Chapter titles of a video, one per line with their start time"),
+				mcp.WithTemplateMIMEType("text/plain"),
+			),
+			Handler: p.vttFile("chapters-text", tools_chapters.Get_videos_videoid_chapters_languageHandler, true),
+		},
+	}
+}
+
+// fromTool adapts a GET tool handler to a resource template handler: the
+// template variables become the tool arguments and the JSON result becomes
+// the resource contents.
+func (p *Provider) fromTool(name string, newHandler tools.NewHandler) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		text, err := tools.Call(metrics.WithOperation(ctx, "resource:"+name), p.cfg.Load(), newHandler, templateArgs(request))
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     text,
+		}}, nil
+	}
+}
+
+// templateArgs returns the variables matched in the resource URI.
+func templateArgs(request mcp.ReadResourceRequest) map[string]any {
+	args := make(map[string]any, len(request.Params.Arguments))
+	for key, val := range request.Params.Arguments {
+		// Template variables arrive as lists of strings
+		if vals, ok := val.([]string); ok && len(vals) > 0 {
+			val = vals[0]
+		}
+		args[key] = val
+	}
+	return args
+}
+
+// listResources answers resources/list with the video library followed by
+// the webhooks, one api.video page per MCP page. The cursor is the base64
+// encoded collection and page number, e.g. "webhooks:2".
+func listResources(ctx context.Context, call rpc.Call) (any, error) {
+	var params mcp.PaginatedParams
+	if len(call.Params) > 0 {
+		if err := json.Unmarshal(call.Params, &params); err != nil {
+			return nil, rpc.InvalidParams(err)
+		}
+	}
+	collection, page := "videos", 1
+	if params.Cursor != "" {
+		decoded, _ := base64.StdEncoding.DecodeString(string(params.Cursor))
+		name, number, _ := strings.Cut(string(decoded), ":")
+		n, err := strconv.Atoi(number)
+		if (name != "videos" && name != "webhooks") || err != nil || n < 1 {
+			return nil, rpc.InvalidParams(fmt.Errorf("invalid cursor"))
+		}
+		collection, page = name, n
+	}
+
+	ctx = metrics.WithOperation(ctx, "resources/list")
+	list := listVideos
+	if collection == "webhooks" {
+		list = listWebhooks
+	}
+	resources, pagesTotal, err := list(ctx, call.Config(), page)
+	if err != nil {
+		return nil, err
+	}
+	result := mcp.ListResourcesResult{Resources: resources}
+	switch {
+	case page < pagesTotal:
+		result.NextCursor = cursor(collection, page+1)
+	case collection == "videos":
+		result.NextCursor = cursor("webhooks", 1)
+	}
+	return result, nil
+}
+
+func cursor(collection string, page int) mcp.Cursor {
+	return mcp.Cursor(base64.StdEncoding.EncodeToString(fmt.Appendf(nil, "%s:%d", collection, page)))
+}
+
+// listVideos returns one page of the video library as resources and the
+// total number of pages.
+func listVideos(ctx context.Context, cfg *config.APIConfig, page int) ([]mcp.Resource, int, error) {
+	var videos []struct {
+		VideoID     string `json:"videoId"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	pagesTotal, err := listPage(ctx, cfg, "/videos", page, &videos)
+	if err != nil {
+		return nil, 0, err
+	}
+	resources := make([]mcp.Resource, 0, len(videos))
+	for _, video := range videos {
+		name := video.Title
+		if name == "" {
+			name = video.VideoID
+		}
+		resources = append(resources, mcp.NewResource("apivideo://videos/"+video.VideoID, name,
+			mcp.WithResourceDescription(video.Description),
+			mcp.WithMIMEType("application/json"),
+		))
+	}
+	return resources, pagesTotal, nil
+}
+
+// listWebhooks returns one page of the registered webhooks as resources and
+// the total number of pages.
+func listWebhooks(ctx context.Context, cfg *config.APIConfig, page int) ([]mcp.Resource, int, error) {
+	var webhooks []struct {
+		WebhookID string   `json:"webhookId"`
+		URL       string   `json:"url"`
+		Events    []string `json:"events"`
+	}
+	pagesTotal, err := listPage(ctx, cfg, "/webhooks", page, &webhooks)
+	if err != nil {
+		return nil, 0, err
+	}
+	resources := make([]mcp.Resource, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		resource := mcp.NewResource("apivideo://webhooks/"+webhook.WebhookID, webhook.URL,
+			mcp.WithMIMEType("application/json"),
+		)
+		if len(webhook.Events) > 0 {
+			resource.Description = "Sent for " + strings.Join(webhook.Events, ", ")
+		}
+		resources = append(resources, resource)
+	}
+	return resources, pagesTotal, nil
+}
+
+// listPage fetches one page of an api.video collection, decoding its items
+// into data, and returns the total number of pages.
+func listPage(ctx context.Context, cfg *config.APIConfig, path string, page int, data any) (int, error) {
+	query := url.Values{}
+	query.Set("currentPage", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(listPageSize))
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s?%s", cfg.BaseURL, path, query.Encode()), nil)
+	if err != nil {
+		return 0, err
+	}
+	if cfg.BearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cfg.HTTPClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("API error: %s", body)
+	}
+
+	var list struct {
+		Data       json.RawMessage `json:"data"`
+		Pagination struct {
+			PagesTotal int `json:"pagesTotal"`
+		} `json:"pagination"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return 0, err
+	}
+	if len(list.Data) > 0 {
+		if err := json.Unmarshal(list.Data, data); err != nil {
+			return 0, err
+		}
+	}
+	return list.Pagination.PagesTotal, nil
+}
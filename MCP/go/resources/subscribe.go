@@ -0,0 +1,348 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/rpc"
+	"github.com/api-video/mcp-server/tools"
+	tools_videos "github.com/api-video/mcp-server/tools/videos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxSubscriptions bounds the resources one session can watch.
+	maxSubscriptions = 100
+	// orphanTimeout stops watches of sessions that have had no notification
+	// stream for that long, such as HTTP clients that went away without
+	// unsubscribing.
+	orphanTimeout = 5 * time.Minute
+)
+
+var statusURI = regexp.MustCompile(`^apivideo://videos/([^/]+)/status$`)
+
+// errDetached means a session has no notification stream.
+var errDetached = errors.New("session has no notification stream")
+
+// errNotOwner means a session's notification stream or watch was opened with
+// other credentials or another client certificate than the call's.
+var errNotOwner = errors.New("the session was opened with other credentials or another client certificate, or has no notification stream")
+
+// Subscriptions serves resources/subscribe and resources/unsubscribe for
+// every MCP server in the process. Each subscribed
+// resource is polled in the background and the session is sent
+// notifications/resources/updated when it changes. Session IDs come from a
+// header, so a session's watches can only be changed, and its notifications
+// only sent, by callers with the owner of its notification stream.
+type Subscriptions struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval func() time.Duration
+
+	mu      sync.Mutex
+	servers map[string]stream                   // session ID → its notification stream
+	watches map[string]map[string]*subscription // session ID → URI → watch
+}
+
+// stream is the server holding a session's notification stream and the
+// owner of the request that opened it, see rpc.Owner.
+type stream struct {
+	server *server.MCPServer
+	owner  string
+}
+
+type subscription struct {
+	owner string
+	stop  context.CancelFunc
+	wake  chan struct{}
+}
+
+// StatusURI is the subscribable status resource of a video.
+func StatusURI(videoID string) string {
+	return "apivideo://videos/" + videoID + "/status"
+}
+
+// LiveStreamURI is the resource of a live stream.
+func LiveStreamURI(liveStreamID string) string {
+	return "apivideo://live-streams/" + liveStreamID
+}
+
+// NewSubscriptions returns an empty registry polling at the interval
+// returned by interval, which is read before each poll.
+func NewSubscriptions(interval func() time.Duration) *Subscriptions {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Subscriptions{
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+		servers:  map[string]stream{},
+		watches:  map[string]map[string]*subscription{},
+	}
+}
+
+// Close stops every watch.
+func (s *Subscriptions) Close() {
+	s.cancel()
+}
+
+// track remembers which server holds each session, and its owner, so
+// notifications found by a watch started from another request's server reach
+// the right stream.
+func (s *Subscriptions) track(mcpSrv *server.MCPServer, owner string, hooks *server.Hooks) {
+	hooks.AddOnRegisterSession(func(_ context.Context, session server.ClientSession) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.servers[session.SessionID()] = stream{server: mcpSrv, owner: owner}
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.servers[session.SessionID()].server == mcpSrv {
+			delete(s.servers, session.SessionID())
+		}
+	})
+}
+
+// Register serves resources/subscribe and resources/unsubscribe on router.
+func (s *Subscriptions) Register(router *rpc.Router) {
+	router.Handle("resources/subscribe", func(_ context.Context, call rpc.Call) (any, error) {
+		uri, err := s.uri(call)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.subscribe(call.SessionID, call.Owner, uri, call.Config); err != nil {
+			return nil, rpc.InvalidParams(err)
+		}
+		return mcp.EmptyResult{}, nil
+	})
+	router.Handle("resources/unsubscribe", func(_ context.Context, call rpc.Call) (any, error) {
+		uri, err := s.uri(call)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.unsubscribe(call.SessionID, call.Owner, uri); err != nil {
+			return nil, rpc.InvalidParams(err)
+		}
+		return mcp.EmptyResult{}, nil
+	})
+}
+
+// uri returns the resource URI of a subscription request.
+func (s *Subscriptions) uri(call rpc.Call) (string, error) {
+	if call.SessionID == "" {
+		return "", &rpc.Error{Code: mcp.INVALID_REQUEST, Message: "subscriptions need an Mcp-Session-Id header"}
+	}
+	var params mcp.SubscribeParams
+	if err := json.Unmarshal(call.Params, &params); err != nil || params.URI == "" {
+		return "", rpc.InvalidParams(fmt.Errorf("missing resource uri"))
+	}
+	return params.URI, nil
+}
+
+// subscribe starts a watch of uri for the session, whose notification stream
+// must have been opened by owner.
+func (s *Subscriptions) subscribe(sessionID, owner, uri string, cfg func() *config.APIConfig) error {
+	match := statusURI.FindStringSubmatch(uri)
+	if match == nil {
+		return fmt.Errorf("subscriptions are only supported for apivideo://videos/{videoId}/status, not %q", uri)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.servers[sessionID]; !ok || current.owner != owner {
+		return errNotOwner
+	}
+	watches := s.watches[sessionID]
+	if _, ok := watches[uri]; ok {
+		return nil
+	}
+	if len(watches) >= maxSubscriptions {
+		return fmt.Errorf("at most %d subscriptions per session", maxSubscriptions)
+	}
+	if watches == nil {
+		watches = map[string]*subscription{}
+		s.watches[sessionID] = watches
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	sub := &subscription{owner: owner, stop: cancel, wake: make(chan struct{}, 1)}
+	watches[uri] = sub
+	go s.watch(ctx, sub, sessionID, uri, match[1], cfg)
+	return nil
+}
+
+// Wake polls every watch of uri now instead of at its next interval, for
+// callers that learn of a change first, such as a webhook receiver. It
+// returns the number of sessions watching uri.
+func (s *Subscriptions) Wake(uri string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, watches := range s.watches {
+		if sub, ok := watches[uri]; ok {
+			n++
+			select {
+			case sub.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+	return n
+}
+
+// unsubscribe stops the watch of uri for the session, if owner started it.
+func (s *Subscriptions) unsubscribe(sessionID, owner, uri string) error {
+	s.mu.Lock()
+	current, ok := s.watches[sessionID][uri]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if current.owner != owner {
+		return errNotOwner
+	}
+	s.remove(sessionID, uri, current)
+	return nil
+}
+
+// remove stops the watch of uri for the session, if it is sub.
+func (s *Subscriptions) remove(sessionID, uri string, sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.watches[sessionID][uri]; !ok || current != sub {
+		return
+	}
+	sub.stop()
+	delete(s.watches[sessionID], uri)
+	if len(s.watches[sessionID]) == 0 {
+		delete(s.watches, sessionID)
+	}
+}
+
+// watch polls the status of a video until the watch is stopped, the video
+// reaches a final state or the session is gone.
+func (s *Subscriptions) watch(ctx context.Context, sub *subscription, sessionID, uri, videoID string, cfg func() *config.APIConfig) {
+	defer s.remove(sessionID, uri, sub)
+	ctx = metrics.WithOperation(ctx, "resource:video-status")
+	log := slog.With("session_id", sessionID, "uri", uri)
+
+	last, lastAttached := "", time.Now()
+	for {
+		state, final, err := videoState(ctx, cfg(), videoID)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			log.WarnContext(ctx, "Polling subscribed resource failed", "error", err)
+		case last != "" && state != last:
+			s.notify(ctx, sessionID, sub.owner, uri)
+		}
+		if err == nil {
+			last = state
+		}
+		if final {
+			log.DebugContext(ctx, "Subscribed video reached its final state, polling stopped")
+			return
+		}
+
+		if s.attached(sessionID, sub.owner) {
+			lastAttached = time.Now()
+		} else if time.Since(lastAttached) > orphanTimeout {
+			log.DebugContext(ctx, "Session has no notification stream, subscription dropped")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.interval()):
+		case <-sub.wake:
+		}
+	}
+}
+
+// Attached reports whether the session has a notification stream.
+func (s *Subscriptions) Attached(sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.servers[sessionID]
+	return ok
+}
+
+// attached reports whether the session has a notification stream opened by
+// owner.
+func (s *Subscriptions) attached(sessionID, owner string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.servers[sessionID]
+	return ok && current.owner == owner
+}
+
+// Send delivers a notification to a session through the server holding its
+// stream.
+func (s *Subscriptions) Send(sessionID, method string, params map[string]any) error {
+	return s.send(sessionID, "", false, method, params)
+}
+
+// send delivers a notification to a session, only through a stream opened by
+// owner if checkOwner is set.
+func (s *Subscriptions) send(sessionID, owner string, checkOwner bool, method string, params map[string]any) error {
+	s.mu.Lock()
+	current, ok := s.servers[sessionID]
+	s.mu.Unlock()
+	if !ok || (checkOwner && current.owner != owner) {
+		return errDetached
+	}
+	return current.server.SendNotificationToSpecificClient(sessionID, method, params)
+}
+
+func (s *Subscriptions) notify(ctx context.Context, sessionID, owner, uri string) {
+	err := s.send(sessionID, owner, true, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+	if errors.Is(err, errDetached) {
+		slog.DebugContext(ctx, "Resource updated but the session has no notification stream", "session_id", sessionID, "uri", uri)
+	} else if err != nil {
+		slog.DebugContext(ctx, "Sending resource update failed", "session_id", sessionID, "uri", uri, "error", err)
+	}
+}
+
+// videoState fetches the status of a video and returns a fingerprint of its
+// ingest and per-quality encoding statuses, and whether they can no longer
+// change.
+func videoState(ctx context.Context, cfg *config.APIConfig, videoID string) (string, bool, error) {
+	text, err := tools.Call(ctx, cfg, tools_videos.Get_video_statusHandler, map[string]any{"videoId": videoID})
+	if err != nil {
+		return "", false, err
+	}
+	var status struct {
+		Ingest struct {
+			Status string `json:"status"`
+		} `json:"ingest"`
+		Encoding struct {
+			Playable  bool `json:"playable"`
+			Qualities []struct {
+				Quality string `json:"quality"`
+				Status  string `json:"status"`
+			} `json:"qualities"`
+		} `json:"encoding"`
+	}
+	if err := json.Unmarshal([]byte(text), &status); err != nil {
+		return "", false, err
+	}
+
+	parts := []string{"ingest=" + status.Ingest.Status, fmt.Sprintf("playable=%t", status.Encoding.Playable)}
+	final := status.Ingest.Status == "uploaded" && status.Encoding.Playable && len(status.Encoding.Qualities) > 0
+	for _, q := range status.Encoding.Qualities {
+		parts = append(parts, q.Quality+"="+q.Status)
+		final = final && (q.Status == "encoded" || q.Status == "failed")
+	}
+	return strings.Join(parts, ","), final, nil
+}
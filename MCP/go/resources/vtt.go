@@ -0,0 +1,119 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxVTTSize bounds the caption and chapter files read into memory.
+const maxVTTSize = 10 << 20
+
+// vttFile serves the WebVTT file a caption or chapter record points to with
+// src, either as is or, with text set, converted to plain text.
+func (p *Provider) vttFile(name string, newHandler tools.NewHandler, text bool) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		ctx = metrics.WithOperation(ctx, "resource:"+name)
+		record, err := tools.Call(ctx, p.cfg.Load(), newHandler, templateArgs(request))
+		if err != nil {
+			return nil, err
+		}
+		var file struct {
+			Src string `json:"src"`
+		}
+		if err := json.Unmarshal([]byte(record), &file); err != nil || file.Src == "" {
+			return nil, fmt.Errorf("no VTT file available for %s", request.Params.URI)
+		}
+
+		vtt, err := p.fetch(ctx, file.Src)
+		if err != nil {
+			return nil, err
+		}
+		contents := mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/vtt", Text: vtt}
+		if text {
+			// Chapters keep their start times, captions become a transcript
+			contents.MIMEType = "text/plain"
+			contents.Text = vttText(vtt, strings.HasPrefix(name, "chapters"))
+		}
+		return []mcp.ResourceContents{contents}, nil
+	}
+}
+
+// fetch downloads a caption or chapter file. The src URLs are served by the
+// api.video CDN and carry their own token for private videos, so no
+// credentials are sent.
+func (p *Provider) fetch(ctx context.Context, src string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", src, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/vtt")
+	resp, err := p.cfg.Load().HTTPClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("fetching %s: %s", src, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxVTTSize))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+var vttTag = regexp.MustCompile(`<[^>]*>`)
+
+// vttText returns the text of the cues in a WebVTT file, one cue per line.
+// Markup and entities are stripped and lines repeated by roll-up captions are
+// dropped. With timestamps set each line starts with the cue start time.
+func vttText(vtt string, timestamps bool) string {
+	var out []string
+	last := ""
+	blocks := strings.Split(strings.ReplaceAll(vtt, "\r\n", "\n"), "\n\n")
+	for _, block := range blocks {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		// Find the timing line; blocks without one are the header, NOTE,
+		// STYLE or REGION blocks
+		timing := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue
+		}
+
+		var cue []string
+		for _, line := range lines[timing+1:] {
+			line = strings.TrimSpace(html.UnescapeString(vttTag.ReplaceAllString(line, "")))
+			if line != "" && line != last {
+				cue = append(cue, line)
+				last = line
+			}
+		}
+		if len(cue) == 0 {
+			continue
+		}
+		text := strings.Join(cue, " ")
+		if timestamps {
+			start, _, _ := strings.Cut(lines[timing], "-->")
+			text = strings.TrimSpace(start) + " " + text
+		}
+		out = append(out, text)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,306 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/api-video/mcp-server/auth"
+	"github.com/api-video/mcp-server/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Call is a JSON-RPC request for a method served by a Router.
+type Call struct {
+	SessionID string
+	// Owner identifies the caller, see Owner. The session ID comes from a
+	// header, so state kept for a session must only be touched by calls
+	// with the owner that created it. STDIO calls have an empty owner.
+	Owner  string
+	Params json.RawMessage
+	// Config returns the credentials of the session. In STDIO mode they
+	// change when the configuration is reloaded.
+	Config func() *config.APIConfig
+}
+
+// Owner returns a key identifying a caller by its api.video endpoint and
+// credentials and its verified client certificate, if any. It is a hash, so
+// it can be kept without keeping the credentials.
+func Owner(cfg *config.APIConfig, identity *auth.Identity) string {
+	h := sha256.New()
+	for _, part := range []string{cfg.BaseURL, cfg.APIKey, cfg.BearerToken, cfg.BasicAuth} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	if identity != nil {
+		h.Write([]byte(identity.Subject))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Handler serves one method. Errors of type *Error keep their code, any other
+// error is reported as an internal error.
+type Handler func(ctx context.Context, call Call) (any, error)
+
+// Error is a JSON-RPC error returned by a Handler.
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// InvalidParams returns an invalid params error with err's message.
+func InvalidParams(err error) error {
+	return &Error{Code: mcp.INVALID_PARAMS, Message: err.Error()}
+}
+
+// Router serves JSON-RPC methods that mcp-go doesn't implement, such as
+// resources/subscribe and completion/complete, in front of the MCP server.
+// Messages for any other method go through untouched.
+type Router struct {
+	methods      map[string]Handler
+	capabilities map[string]any
+}
+
+// NewRouter returns a Router without methods.
+func NewRouter() *Router {
+	return &Router{methods: map[string]Handler{}, capabilities: map[string]any{}}
+}
+
+// Capability adds a server capability to the initialize responses, for
+// capabilities mcp-go doesn't know about. Capabilities must be added before
+// the router starts serving.
+func (r *Router) Capability(name string, value any) {
+	r.capabilities[name] = value
+}
+
+// Handle registers the handler for method. Methods must be registered before
+// the router starts serving.
+func (r *Router) Handle(method string, handler Handler) {
+	r.methods[method] = handler
+}
+
+// maxMessage bounds the size of a message posted to the HTTP endpoint.
+const maxMessage = 4 << 20
+
+type request struct {
+	ID     mcp.RequestId   `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// Serve answers message if it is a request for a registered method,
+// returning false for any other message, including batches.
+func (r *Router) Serve(ctx context.Context, call Call, message []byte) (mcp.JSONRPCMessage, bool) {
+	request, handler, ok := r.route(message)
+	if !ok {
+		return nil, false
+	}
+	return answer(ctx, call, request, handler), true
+}
+
+// route returns the request in message and its handler, if it is a request
+// for a registered method.
+func (r *Router) route(message []byte) (request, Handler, bool) {
+	var request request
+	if json.Unmarshal(message, &request) != nil {
+		return request, nil, false
+	}
+	handler, ok := r.methods[request.Method]
+	return request, handler, ok
+}
+
+// answer runs handler for request and returns the response.
+func answer(ctx context.Context, call Call, request request, handler Handler) mcp.JSONRPCMessage {
+	call.Params = request.Params
+	result, err := handler(ctx, call)
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: mcp.INTERNAL_ERROR, Message: err.Error()}
+		}
+		return mcp.NewJSONRPCError(request.ID, rpcErr.Code, rpcErr.Message, nil)
+	}
+	return mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: request.ID, Result: result}
+}
+
+// patchInitialize adds the router's capabilities to an initialize response.
+// Anything it can't parse is returned unchanged.
+func (r *Router) patchInitialize(response []byte) []byte {
+	var msg map[string]json.RawMessage
+	if len(r.capabilities) == 0 || json.Unmarshal(response, &msg) != nil {
+		return response
+	}
+	var result map[string]json.RawMessage
+	if json.Unmarshal(msg["result"], &result) != nil {
+		return response
+	}
+	capabilities := map[string]any{}
+	if json.Unmarshal(result["capabilities"], &capabilities) != nil {
+		return response
+	}
+	for name, value := range r.capabilities {
+		capabilities[name] = value
+	}
+	result["capabilities"], _ = json.Marshal(capabilities)
+	msg["result"], _ = json.Marshal(result)
+	patched, err := json.Marshal(msg)
+	if err != nil {
+		return response
+	}
+	return patched
+}
+
+// HandleHTTP answers requests posted to the streamable HTTP endpoint for
+// registered methods and passes every other request to next, with its body
+// intact. Bodies over 4 MiB are refused with 413.
+func (r *Router) HandleHTTP(w http.ResponseWriter, req *http.Request, cfg *config.APIConfig, next http.Handler) {
+	if req.Method != http.MethodPost {
+		next.ServeHTTP(w, req)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxMessage))
+	req.Body.Close()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	identity, _ := auth.FromRequest(req)
+	call := Call{
+		SessionID: req.Header.Get(server.HeaderKeySessionID),
+		Owner:     Owner(cfg, identity),
+		Config:    func() *config.APIConfig { return cfg },
+	}
+	if response, ok := r.Serve(req.Context(), call, body); ok {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	var msg request
+	if json.Unmarshal(body, &msg) != nil || msg.Method != string(mcp.MethodInitialize) {
+		next.ServeHTTP(w, req)
+		return
+	}
+	// Buffer the initialize response to add the capabilities
+	buf := &responseBuffer{header: w.Header(), status: http.StatusOK}
+	next.ServeHTTP(buf, req)
+	out := buf.body.Bytes()
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		out = r.patchInitialize(bytes.TrimSpace(out))
+		w.Header().Del("Content-Length")
+	}
+	w.WriteHeader(buf.status)
+	w.Write(out)
+}
+
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header         { return b.header }
+func (b *responseBuffer) WriteHeader(status int)      { b.status = status }
+func (b *responseBuffer) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+// Stdio wraps the STDIO streams so requests for registered methods are
+// answered here and every other message reaches the MCP server. Registered
+// methods run in the background, so a slow one doesn't hold up the messages
+// read after it. Writes to the returned writer are serialized with the
+// responses written by the router.
+func (r *Router) Stdio(ctx context.Context, in io.Reader, out io.Writer, sessionID string, cfg func() *config.APIConfig) (io.Reader, io.Writer) {
+	w := &stdioWriter{router: r, w: out, initialize: map[string]bool{}}
+	return &stdioFilter{ctx: ctx, router: r, in: bufio.NewReader(in), out: w, call: Call{SessionID: sessionID, Config: cfg}}, w
+}
+
+// stdioWriter serializes writes, which mcp-go makes one line at a time, and
+// patches the responses to initialize requests.
+type stdioWriter struct {
+	router     *Router
+	mu         sync.Mutex
+	w          io.Writer
+	initialize map[string]bool // IDs of initialize requests awaiting a response
+}
+
+func (w *stdioWriter) expectInitialize(id mcp.RequestId) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.initialize[id.String()] = true
+}
+
+func (w *stdioWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.initialize) > 0 {
+		var response struct {
+			ID mcp.RequestId `json:"id"`
+		}
+		if json.Unmarshal(p, &response) == nil && w.initialize[response.ID.String()] {
+			delete(w.initialize, response.ID.String())
+			patched := append(w.router.patchInitialize(bytes.TrimSpace(p)), '\n')
+			if _, err := w.w.Write(patched); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		}
+	}
+	return w.w.Write(p)
+}
+
+type stdioFilter struct {
+	ctx     context.Context
+	router  *Router
+	in      *bufio.Reader
+	out     *stdioWriter
+	call    Call
+	pending []byte
+	running sync.WaitGroup // handlers still answering
+}
+
+func (f *stdioFilter) Read(p []byte) (int, error) {
+	for len(f.pending) == 0 {
+		line, err := f.in.ReadBytes('\n')
+		if len(line) > 0 {
+			if request, handler, ok := f.router.route(line); ok {
+				f.running.Add(1)
+				go func() {
+					defer f.running.Done()
+					out, _ := json.Marshal(answer(f.ctx, f.call, request, handler))
+					f.out.Write(append(out, '\n'))
+				}()
+				line = nil
+			} else if request.Method == string(mcp.MethodInitialize) {
+				f.out.expectInitialize(request.ID)
+			}
+		}
+		f.pending = line
+		if err != nil && len(f.pending) == 0 {
+			// Let running handlers write their responses before the server stops
+			f.running.Wait()
+			return 0, err
+		}
+	}
+	n := copy(p, f.pending)
+	f.pending = f.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,140 @@
+package shutdown
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Drainer coordinates a graceful shutdown: once Drain is called new sessions
+// and tool calls are refused, running tool calls get the drain period to
+// finish and are then cancelled.
+type Drainer struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	draining atomic.Bool
+
+	mu      sync.Mutex
+	running map[*call]struct{}
+	idle    chan struct{} // closed when running becomes empty during a drain
+}
+
+type call struct {
+	tool    string
+	started time.Time
+}
+
+// New returns a Drainer ready to track tool calls.
+func New() *Drainer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Drainer{ctx: ctx, cancel: cancel, running: map[*call]struct{}{}}
+}
+
+// Context is cancelled when the drain period ends. Use it as the base context
+// of servers so long-lived streams are closed too.
+func (d *Drainer) Context() context.Context {
+	return d.ctx
+}
+
+// Draining reports whether a shutdown is in progress.
+func (d *Drainer) Draining() bool {
+	return d.draining.Load()
+}
+
+// HTTPMiddleware refuses requests that would start a new MCP session (those
+// without an Mcp-Session-Id header) while draining, so load balancers retry
+// them on another instance.
+func (d *Drainer) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if d.Draining() && r.Header.Get(server.HeaderKeySessionID) == "" {
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ToolMiddleware tracks running tool calls, refuses new ones while draining
+// and cancels the call's context when the drain period runs out.
+func (d *Drainer) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c := &call{tool: request.Params.Name, started: time.Now()}
+		if !d.add(c) {
+			return mcp.NewToolResultError("Server is shutting down, retry the call later"), nil
+		}
+		defer d.done(c)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stop := context.AfterFunc(d.ctx, cancel)
+		defer stop()
+		return next(ctx, request)
+	}
+}
+
+func (d *Drainer) add(c *call) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining.Load() {
+		return false
+	}
+	d.running[c] = struct{}{}
+	return true
+}
+
+func (d *Drainer) done(c *call) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.running, c)
+	if len(d.running) == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// Drain stops accepting work and waits up to timeout for running tool calls.
+// Calls still running afterwards are cancelled, and Drain waits up to grace
+// for them to return. Context is cancelled before Drain returns.
+func (d *Drainer) Drain(timeout, grace time.Duration) {
+	d.mu.Lock()
+	d.draining.Store(true)
+	idle := make(chan struct{})
+	if len(d.running) == 0 {
+		close(idle)
+	} else {
+		d.idle = idle
+		slog.Info("Waiting for running tool calls", "count", len(d.running), "drain_timeout", timeout)
+	}
+	d.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-idle:
+		d.cancel()
+		return
+	case <-timer.C:
+	}
+
+	d.mu.Lock()
+	for c := range d.running {
+		slog.Warn("Cancelling tool call at end of drain period", "tool", c.tool, "running_ms", time.Since(c.started).Milliseconds())
+	}
+	d.mu.Unlock()
+	d.cancel()
+
+	timer.Reset(grace)
+	select {
+	case <-idle:
+	case <-timer.C:
+		slog.Warn("Tool calls did not return after cancellation")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -14,8 +15,11 @@ import (
 
 func Get_accountHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := bind.Arguments(request, &struct{}{}); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 		url := fmt.Sprintf("%s/account", cfg.BaseURL)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -25,7 +29,7 @@ func Get_accountHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -2,93 +2,43 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"strings"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/pagination"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// get_analytics_live_streams_livestreamidArgs are the arguments of get_analytics_live-streams_liveStreamId.
+type get_analytics_live_streams_livestreamidArgs struct {
+	LiveStreamID string `path:"liveStreamId"`
+	Period       string `query:"period"`
+	CurrentPage  int    `query:"currentPage"`
+	PageSize     int    `query:"pageSize"`
+	pagination.Args
+}
+
 func Get_analytics_live_streams_livestreamidHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		liveStreamIdVal, ok := args["liveStreamId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: liveStreamId"), nil
-		}
-		liveStreamId, ok := liveStreamIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: liveStreamId"), nil
-		}
-		queryParams := make([]string, 0)
-		if val, ok := args["period"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("period=%v", val))
-		}
-		if val, ok := args["currentPage"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("currentPage=%v", val))
-		}
-		if val, ok := args["pageSize"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("pageSize=%v", val))
-		}
-		queryString := ""
-		if len(queryParams) > 0 {
-			queryString = "?" + strings.Join(queryParams, "&")
-		}
-		url := fmt.Sprintf("%s/analytics/live-streams/%s%s", cfg.BaseURL, liveStreamId, queryString)
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
-		}
-		// Set authentication based on auth type
-		if cfg.BearerToken != "" {
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
+		var args get_analytics_live_streams_livestreamidArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to read response body", err), nil
-		}
-
-		if resp.StatusCode >= 400 {
-			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
-		}
-		// Use properly typed response
-		var result models.GeneratedType
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
-		}
-
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		url := bind.URL(cfg.BaseURL+"/analytics/live-streams/{liveStreamId}", args)
+		return pagination.List(ctx, cfg, url, args.Args)
 	}
 }
 
 func CreateGet_analytics_live_streams_livestreamidTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_analytics_live-streams_liveStreamId",
 		mcp.WithDescription("List live stream player sessions"),
-		mcp.WithString("liveStreamId", mcp.Required(), mcp.Description("The unique identifier for the live stream you want to retrieve analytics for.")),
-		mcp.WithString("period", mcp.Description("Period must have one of the following formats: \n- For a day : \"2018-01-01\",\n- For a week: \"2018-W01\", \n- For a month: \"2018-01\"\n- For a year: \"2018\"\nFor a range period: \n-  Date range: \"2018-01-01/2018-01-15\"\n")),
-		mcp.WithNumber("currentPage", mcp.Description("Choose the number of search results to return per page. Minimum value: 1")),
-		mcp.WithNumber("pageSize", mcp.Description("Results per page. Allowed values 1-100, default is 25.")),
+		mcp.WithString("liveStreamId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the live stream you want to retrieve analytics for.")),
+		mcp.WithString("period", mcp.Pattern(validate.Period), mcp.Description("Period must have one of the following formats: \n- For a day : \"2018-01-01\",\n- For a week: \"2018-W01\", \n- For a month: \"2018-01\"\n- For a year: \"2018\"\nFor a range period: \n-  Date range: \"2018-01-01/2018-01-15\"\n")),
+		mcp.WithNumber("currentPage", mcp.Min(1), mcp.Description("Choose the number of search results to return per page. Minimum value: 1")),
+		mcp.WithNumber("pageSize", mcp.Min(1), mcp.Max(100), mcp.Description("Results per page. Allowed values 1-100, default is 25.")),
+		pagination.WithCursor(),
 	)
 
 	return models.Tool{
@@ -2,89 +2,41 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"strings"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/pagination"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// get_analytics_sessions_sessionid_eventsArgs are the arguments of get_analytics_sessions_sessionId_events.
+type get_analytics_sessions_sessionid_eventsArgs struct {
+	SessionID   string `path:"sessionId"`
+	CurrentPage int    `query:"currentPage"`
+	PageSize    int    `query:"pageSize"`
+	pagination.Args
+}
+
 func Get_analytics_sessions_sessionid_eventsHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		sessionIdVal, ok := args["sessionId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: sessionId"), nil
-		}
-		sessionId, ok := sessionIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: sessionId"), nil
-		}
-		queryParams := make([]string, 0)
-		if val, ok := args["currentPage"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("currentPage=%v", val))
+		var args get_analytics_sessions_sessionid_eventsArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		if val, ok := args["pageSize"]; ok {
-			queryParams = append(queryParams, fmt.Sprintf("pageSize=%v", val))
-		}
-		queryString := ""
-		if len(queryParams) > 0 {
-			queryString = "?" + strings.Join(queryParams, "&")
-		}
-		url := fmt.Sprintf("%s/analytics/sessions/%s/events%s", cfg.BaseURL, sessionId, queryString)
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
-		}
-		// Set authentication based on auth type
-		if cfg.BearerToken != "" {
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
-		}
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to read response body", err), nil
-		}
-
-		if resp.StatusCode >= 400 {
-			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
-		}
-		// Use properly typed response
-		var result models.GeneratedType
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
-		}
-
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		url := bind.URL(cfg.BaseURL+"/analytics/sessions/{sessionId}/events", args)
+		return pagination.List(ctx, cfg, url, args.Args)
 	}
 }
 
 func CreateGet_analytics_sessions_sessionid_eventsTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_analytics_sessions_sessionId_events",
 		mcp.WithDescription("List player session events"),
-		mcp.WithString("sessionId", mcp.Required(), mcp.Description("A unique identifier you can use to reference and track a session with.")),
-		mcp.WithNumber("currentPage", mcp.Description("Choose the number of search results to return per page. Minimum value: 1")),
-		mcp.WithNumber("pageSize", mcp.Description("Results per page. Allowed values 1-100, default is 25.")),
+		mcp.WithString("sessionId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("A unique identifier you can use to reference and track a session with.")),
+		mcp.WithNumber("currentPage", mcp.Min(1), mcp.Description("Choose the number of search results to return per page. Minimum value: 1")),
+		mcp.WithNumber("pageSize", mcp.Min(1), mcp.Max(100), mcp.Description("Results per page. Allowed values 1-100, default is 25.")),
+		pagination.WithCursor(),
 	)
 
 	return models.Tool{
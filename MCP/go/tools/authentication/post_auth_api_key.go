@@ -8,35 +8,29 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// post_auth_api_keyArgs are the arguments of post_auth_api-key.
+type post_auth_api_keyArgs struct {
+	APIKey string `body:"apiKey"`
+}
+
 func Post_auth_api_keyHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
+		var args post_auth_api_keyArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/auth/api-key", cfg.BaseURL)
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+		url := bind.URL(cfg.BaseURL+"/auth/api-key", args)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
@@ -47,7 +41,7 @@ func Post_auth_api_keyHandler(cfg *config.APIConfig) func(ctx context.Context, r
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -80,7 +74,7 @@ func Post_auth_api_keyHandler(cfg *config.APIConfig) func(ctx context.Context, r
 func CreatePost_auth_api_keyTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_auth_api-key",
 		mcp.WithDescription("Authenticate"),
-		mcp.WithString("apiKey", mcp.Required(), mcp.Description("Input parameter: Your account API key. You can use your sandbox API key, or you can use your production API key.")),
+		mcp.WithString("apiKey", mcp.Required(), mcp.MinLength(1), mcp.Description("Input parameter: Your account API key. You can use your sandbox API key, or you can use your production API key.")),
 	)
 
 	return models.Tool{
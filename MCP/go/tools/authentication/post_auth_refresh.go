@@ -8,35 +8,29 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// post_auth_refreshArgs are the arguments of post_auth_refresh.
+type post_auth_refreshArgs struct {
+	RefreshToken string `body:"refreshToken"`
+}
+
 func Post_auth_refreshHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
+		var args post_auth_refreshArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/auth/refresh", cfg.BaseURL)
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+		url := bind.URL(cfg.BaseURL+"/auth/refresh", args)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
@@ -47,7 +41,7 @@ func Post_auth_refreshHandler(cfg *config.APIConfig) func(ctx context.Context, r
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -80,7 +74,7 @@ func Post_auth_refreshHandler(cfg *config.APIConfig) func(ctx context.Context, r
 func CreatePost_auth_refreshTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_auth_refresh",
 		mcp.WithDescription("Refresh token"),
-		mcp.WithString("refreshToken", mcp.Required(), mcp.Description("Input parameter: The refresh token is either the first refresh token you received when you authenticated with the auth/api-key endpoint, or it's the refresh token from the last time you used the auth/refresh endpoint. Place this in the body of your request to obtain a new access token (which is valid for an hour) and a new refresh token.\n")),
+		mcp.WithString("refreshToken", mcp.Required(), mcp.MinLength(1), mcp.Description("Input parameter: The refresh token is either the first refresh token you received when you authenticated with the auth/api-key endpoint, or it's the refresh token from the last time you used the auth/refresh endpoint. Place this in the body of your request to obtain a new access token (which is valid for an hour) and a new refresh token.\n")),
 	)
 
 	return models.Tool{
@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"context"
+	"errors"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// NewHandler builds a tool handler for a set of credentials, like the
+// XxxHandler functions of the tool packages.
+type NewHandler func(*config.APIConfig) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+// Call runs a tool handler outside of an MCP tool call, for resources,
+// completions and composite tools, and returns its text result. A failed call
+// returns its error text as the error.
+func Call(ctx context.Context, cfg *config.APIConfig, newHandler NewHandler, args map[string]any) (string, error) {
+	var call mcp.CallToolRequest
+	call.Params.Arguments = args
+	result, err := newHandler(cfg)(ctx, call)
+	if err != nil {
+		return "", err
+	}
+	text := ResultText(result)
+	if result.IsError {
+		return "", errors.New(text)
+	}
+	return text, nil
+}
+
+// ResultText returns the first text content of a tool result.
+func ResultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
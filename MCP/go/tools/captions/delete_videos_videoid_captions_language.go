@@ -7,35 +7,27 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// delete_videos_videoid_captions_languageArgs are the arguments of delete_videos_videoId_captions_language.
+type delete_videos_videoid_captions_languageArgs struct {
+	VideoID  string `path:"videoId"`
+	Language string `path:"language"`
+}
+
 func Delete_videos_videoid_captions_languageHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		videoIdVal, ok := args["videoId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: videoId"), nil
-		}
-		videoId, ok := videoIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: videoId"), nil
-		}
-		languageVal, ok := args["language"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: language"), nil
-		}
-		language, ok := languageVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: language"), nil
+		var args delete_videos_videoid_captions_languageArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		url := fmt.Sprintf("%s/videos/%s/captions/%s", cfg.BaseURL, videoId, language)
-		req, err := http.NewRequest("DELETE", url, nil)
+		url := bind.URL(cfg.BaseURL+"/videos/{videoId}/captions/{language}", args)
+		req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -45,7 +37,7 @@ func Delete_videos_videoid_captions_languageHandler(cfg *config.APIConfig) func(
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -78,8 +70,8 @@ func Delete_videos_videoid_captions_languageHandler(cfg *config.APIConfig) func(
 func CreateDelete_videos_videoid_captions_languageTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("delete_videos_videoId_captions_language",
 		mcp.WithDescription("Delete a caption"),
-		mcp.WithString("videoId", mcp.Required(), mcp.Description("The unique identifier for the video you want to delete a caption from.")),
-		mcp.WithString("language", mcp.Required(), mcp.Description("A valid [BCP 47](https://github.com/libyal/libfwnt/wiki/Language-Code-identifiers) language representation.")),
+		mcp.WithString("videoId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the video you want to delete a caption from.")),
+		mcp.WithString("language", mcp.Required(), mcp.Pattern(validate.Language), mcp.Description("A valid [BCP 47](https://github.com/libyal/libfwnt/wiki/Language-Code-identifiers) language representation.")),
 	)
 
 	return models.Tool{
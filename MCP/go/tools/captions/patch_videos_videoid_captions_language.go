@@ -8,62 +8,43 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// patch_videos_videoid_captions_languageArgs are the arguments of patch_videos_videoId_captions_language.
+type patch_videos_videoid_captions_languageArgs struct {
+	VideoID  string `path:"videoId"`
+	Language string `path:"language"`
+	Default  *bool  `body:"default"`
+}
+
 func Patch_videos_videoid_captions_languageHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		videoIdVal, ok := args["videoId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: videoId"), nil
-		}
-		videoId, ok := videoIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: videoId"), nil
-		}
-		languageVal, ok := args["language"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: language"), nil
+		var args patch_videos_videoid_captions_languageArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		language, ok := languageVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: language"), nil
-		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
-		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/videos/%s/captions/%s", cfg.BaseURL, videoId, language)
-		req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(bodyBytes))
-		req.Header.Set("Content-Type", "application/json")
+		url := bind.URL(cfg.BaseURL+"/videos/{videoId}/captions/{language}", args)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(bodyBytes))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
+		req.Header.Set("Content-Type", "application/json")
 		// Set authentication based on auth type
 		if cfg.BearerToken != "" {
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -96,8 +77,8 @@ func Patch_videos_videoid_captions_languageHandler(cfg *config.APIConfig) func(c
 func CreatePatch_videos_videoid_captions_languageTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("patch_videos_videoId_captions_language",
 		mcp.WithDescription("Update caption"),
-		mcp.WithString("videoId", mcp.Required(), mcp.Description("The unique identifier for the video you want to have automatic captions for. ")),
-		mcp.WithString("language", mcp.Required(), mcp.Description("A valid [BCP 47](https://github.com/libyal/libfwnt/wiki/Language-Code-identifiers) language representation.")),
+		mcp.WithString("videoId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the video you want to have automatic captions for. ")),
+		mcp.WithString("language", mcp.Required(), mcp.Pattern(validate.Language), mcp.Description("A valid [BCP 47](https://github.com/libyal/libfwnt/wiki/Language-Code-identifiers) language representation.")),
 		mcp.WithBoolean("default", mcp.Description("")),
 	)
 
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/api-video/mcp-server/bind"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// post_videos_videoid_captions_languageArgs are the arguments of post_videos_videoId_captions_language.
+type post_videos_videoid_captions_languageArgs struct {
+	VideoID  string `path:"videoId"`
+	Language string `path:"language"`
+	Content  string `arg:"content"`
+}
+
+func Post_videos_videoid_captions_languageHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args post_videos_videoid_captions_languageArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// api.video takes the caption as a file upload
+		var bodyBuf bytes.Buffer
+		form := multipart.NewWriter(&bodyBuf)
+		part, err := form.CreateFormFile("file", args.Language+".vtt")
+		if err == nil {
+			_, err = io.WriteString(part, args.Content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
+		}
+		url := bind.URL(cfg.BaseURL+"/videos/{videoId}/captions/{language}", args)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, &bodyBuf)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
+		}
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		// Set authentication based on auth type
+		if cfg.BearerToken != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := cfg.HTTPClient().Do(req)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to read response body", err), nil
+		}
+
+		if resp.StatusCode >= 400 {
+			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
+		}
+		// Use properly typed response
+		var result models.Subtitle
+		if err := json.Unmarshal(body, &result); err != nil {
+			// Fallback to raw text if unmarshaling fails
+			return mcp.NewToolResultText(string(body)), nil
+		}
+
+		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+		}
+
+		return mcp.NewToolResultText(string(prettyJSON)), nil
+	}
+}
+
+func CreatePost_videos_videoid_captions_languageTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("post_videos_videoId_captions_language",
+		mcp.WithDescription("Upload a caption"),
+		mcp.WithString("videoId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the video you want to add a caption to.")),
+		mcp.WithString("language", mcp.Required(), mcp.Pattern(validate.Language), mcp.Description("A valid [BCP 47](https://github.com/libyal/libfwnt/wiki/Language-Code-identifiers) language representation.")),
+		mcp.WithString("content", mcp.Required(), mcp.MinLength(1), mcp.Description("The caption in WebVTT format, starting with WEBVTT.")),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Post_videos_videoid_captions_languageHandler(cfg),
+	}
+}
@@ -7,35 +7,27 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// get_videos_videoid_chapters_languageArgs are the arguments of get_videos_videoId_chapters_language.
+type get_videos_videoid_chapters_languageArgs struct {
+	VideoID  string `path:"videoId"`
+	Language string `path:"language"`
+}
+
 func Get_videos_videoid_chapters_languageHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		videoIdVal, ok := args["videoId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: videoId"), nil
-		}
-		videoId, ok := videoIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: videoId"), nil
-		}
-		languageVal, ok := args["language"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: language"), nil
-		}
-		language, ok := languageVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: language"), nil
+		var args get_videos_videoid_chapters_languageArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		url := fmt.Sprintf("%s/videos/%s/chapters/%s", cfg.BaseURL, videoId, language)
-		req, err := http.NewRequest("GET", url, nil)
+		url := bind.URL(cfg.BaseURL+"/videos/{videoId}/chapters/{language}", args)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -45,7 +37,7 @@ func Get_videos_videoid_chapters_languageHandler(cfg *config.APIConfig) func(ctx
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -78,8 +70,8 @@ func Get_videos_videoid_chapters_languageHandler(cfg *config.APIConfig) func(ctx
 func CreateGet_videos_videoid_chapters_languageTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_videos_videoId_chapters_language",
 		mcp.WithDescription("Show a chapter"),
-		mcp.WithString("videoId", mcp.Required(), mcp.Description("The unique identifier for the video you want to show a chapter for.")),
-		mcp.WithString("language", mcp.Required(), mcp.Description("A valid [BCP 47](https://github.com/libyal/libfwnt/wiki/Language-Code-identifiers) language representation.")),
+		mcp.WithString("videoId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the video you want to show a chapter for.")),
+		mcp.WithString("language", mcp.Required(), mcp.Pattern(validate.Language), mcp.Description("A valid [BCP 47](https://github.com/libyal/libfwnt/wiki/Language-Code-identifiers) language representation.")),
 	)
 
 	return models.Tool{
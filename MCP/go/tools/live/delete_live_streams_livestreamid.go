@@ -7,27 +7,26 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// delete_live_streams_livestreamidArgs are the arguments of delete_live-streams_liveStreamId.
+type delete_live_streams_livestreamidArgs struct {
+	LiveStreamID string `path:"liveStreamId"`
+}
+
 func Delete_live_streams_livestreamidHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		liveStreamIdVal, ok := args["liveStreamId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: liveStreamId"), nil
-		}
-		liveStreamId, ok := liveStreamIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: liveStreamId"), nil
+		var args delete_live_streams_livestreamidArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		url := fmt.Sprintf("%s/live-streams/%s", cfg.BaseURL, liveStreamId)
-		req, err := http.NewRequest("DELETE", url, nil)
+		url := bind.URL(cfg.BaseURL+"/live-streams/{liveStreamId}", args)
+		req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -37,7 +36,7 @@ func Delete_live_streams_livestreamidHandler(cfg *config.APIConfig) func(ctx con
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -70,7 +69,7 @@ func Delete_live_streams_livestreamidHandler(cfg *config.APIConfig) func(ctx con
 func CreateDelete_live_streams_livestreamidTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("delete_live-streams_liveStreamId",
 		mcp.WithDescription("Delete a live stream"),
-		mcp.WithString("liveStreamId", mcp.Required(), mcp.Description("The unique ID for the live stream that you want to remove.")),
+		mcp.WithString("liveStreamId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique ID for the live stream that you want to remove.")),
 	)
 
 	return models.Tool{
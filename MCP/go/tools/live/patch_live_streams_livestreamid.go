@@ -8,54 +8,45 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// patch_live_streams_livestreamidArgs are the arguments of patch_live-streams_liveStreamId.
+type patch_live_streams_livestreamidArgs struct {
+	LiveStreamID string  `path:"liveStreamId"`
+	Public       *bool   `body:"public"`
+	Record       *bool   `body:"record"`
+	Name         *string `body:"name"`
+	PlayerID     *string `body:"playerId"`
+}
+
 func Patch_live_streams_livestreamidHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		liveStreamIdVal, ok := args["liveStreamId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: liveStreamId"), nil
-		}
-		liveStreamId, ok := liveStreamIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: liveStreamId"), nil
+		var args patch_live_streams_livestreamidArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
-		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/live-streams/%s", cfg.BaseURL, liveStreamId)
-		req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(bodyBytes))
-		req.Header.Set("Content-Type", "application/json")
+		url := bind.URL(cfg.BaseURL+"/live-streams/{liveStreamId}", args)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(bodyBytes))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
+		req.Header.Set("Content-Type", "application/json")
 		// Set authentication based on auth type
 		if cfg.BearerToken != "" {
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -88,11 +79,11 @@ func Patch_live_streams_livestreamidHandler(cfg *config.APIConfig) func(ctx cont
 func CreatePatch_live_streams_livestreamidTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("patch_live-streams_liveStreamId",
 		mcp.WithDescription("Update a live stream"),
-		mcp.WithString("liveStreamId", mcp.Required(), mcp.Description("The unique ID for the live stream that you want to update information for such as player details, or whether you want the recording on or off.")),
+		mcp.WithString("liveStreamId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique ID for the live stream that you want to update information for such as player details, or whether you want the recording on or off.")),
 		mcp.WithBoolean("public", mcp.Description("Input parameter: BETA FEATURE Please limit all public = false (\"private\") livestreams to 3,000 users. Whether your video can be viewed by everyone, or requires authentication to see it. A setting of false will require a unique token for each view.")),
 		mcp.WithBoolean("record", mcp.Description("Input parameter: Use this to indicate whether you want the recording on or off. On is true, off is false.")),
-		mcp.WithString("name", mcp.Description("Input parameter: The name you want to use for your live stream.")),
-		mcp.WithString("playerId", mcp.Description("Input parameter: The unique ID for the player associated with a live stream that you want to update.")),
+		mcp.WithString("name", mcp.MinLength(1), mcp.Description("Input parameter: The name you want to use for your live stream.")),
+		mcp.WithString("playerId", mcp.Pattern(validate.ID), mcp.Description("Input parameter: The unique ID for the player associated with a live stream that you want to update.")),
 	)
 
 	return models.Tool{
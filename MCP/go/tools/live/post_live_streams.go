@@ -8,35 +8,33 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// post_live_streamsArgs are the arguments of post_live-streams.
+type post_live_streamsArgs struct {
+	PlayerID *string `body:"playerId"`
+	Public   *bool   `body:"public"`
+	Record   *bool   `body:"record"`
+	Name     string  `body:"name"`
+}
+
 func Post_live_streamsHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
+		var args post_live_streamsArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/live-streams", cfg.BaseURL)
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+		url := bind.URL(cfg.BaseURL+"/live-streams", args)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
@@ -47,7 +45,7 @@ func Post_live_streamsHandler(cfg *config.APIConfig) func(ctx context.Context, r
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -80,10 +78,10 @@ func Post_live_streamsHandler(cfg *config.APIConfig) func(ctx context.Context, r
 func CreatePost_live_streamsTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_live-streams",
 		mcp.WithDescription("Create live stream"),
-		mcp.WithString("playerId", mcp.Description("Input parameter: The unique identifier for the player.")),
+		mcp.WithString("playerId", mcp.Pattern(validate.ID), mcp.Description("Input parameter: The unique identifier for the player.")),
 		mcp.WithBoolean("public", mcp.Description("Input parameter: BETA FEATURE Please limit all public = false (\"private\") livestreams to 3,000 users. Whether your video can be viewed by everyone, or requires authentication to see it. A setting of false will require a unique token for each view.")),
 		mcp.WithBoolean("record", mcp.Description("Input parameter: Whether you are recording or not. True for record, false for not record.")),
-		mcp.WithString("name", mcp.Required(), mcp.Description("Input parameter: Add a name for your live stream here.")),
+		mcp.WithString("name", mcp.Required(), mcp.MinLength(1), mcp.Description("Input parameter: Add a name for your live stream here.")),
 	)
 
 	return models.Tool{
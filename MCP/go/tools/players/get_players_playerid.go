@@ -7,27 +7,26 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// get_players_playeridArgs are the arguments of get_players_playerId.
+type get_players_playeridArgs struct {
+	PlayerID string `path:"playerId"`
+}
+
 func Get_players_playeridHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		playerIdVal, ok := args["playerId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: playerId"), nil
-		}
-		playerId, ok := playerIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: playerId"), nil
+		var args get_players_playeridArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		url := fmt.Sprintf("%s/players/%s", cfg.BaseURL, playerId)
-		req, err := http.NewRequest("GET", url, nil)
+		url := bind.URL(cfg.BaseURL+"/players/{playerId}", args)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -37,7 +36,7 @@ func Get_players_playeridHandler(cfg *config.APIConfig) func(ctx context.Context
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -70,7 +69,7 @@ func Get_players_playeridHandler(cfg *config.APIConfig) func(ctx context.Context
 func CreateGet_players_playeridTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_players_playerId",
 		mcp.WithDescription("Show a player"),
-		mcp.WithString("playerId", mcp.Required(), mcp.Description("The unique identifier for the player you want to retrieve. ")),
+		mcp.WithString("playerId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the player you want to retrieve. ")),
 	)
 
 	return models.Tool{
@@ -8,54 +8,55 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// patch_players_playeridArgs are the arguments of patch_players_playerId.
+type patch_players_playeridArgs struct {
+	PlayerID         string  `path:"playerId"`
+	BackgroundTop    *string `body:"backgroundTop"`
+	EnableApi        *bool   `body:"enableApi"`
+	EnableControls   *bool   `body:"enableControls"`
+	ForceLoop        *bool   `body:"forceLoop"`
+	HideTitle        *bool   `body:"hideTitle"`
+	LinkHover        *string `body:"linkHover"`
+	TrackUnplayed    *string `body:"trackUnplayed"`
+	TrackPlayed      *string `body:"trackPlayed"`
+	TrackBackground  *string `body:"trackBackground"`
+	BackgroundBottom *string `body:"backgroundBottom"`
+	BackgroundText   *string `body:"backgroundText"`
+	ForceAutoplay    *bool   `body:"forceAutoplay"`
+	Link             *string `body:"link"`
+	Text             *string `body:"text"`
+}
+
 func Patch_players_playeridHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		playerIdVal, ok := args["playerId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: playerId"), nil
-		}
-		playerId, ok := playerIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: playerId"), nil
+		var args patch_players_playeridArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.PlayerUpdatePayload
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
-		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/players/%s", cfg.BaseURL, playerId)
-		req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(bodyBytes))
-		req.Header.Set("Content-Type", "application/json")
+		url := bind.URL(cfg.BaseURL+"/players/{playerId}", args)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(bodyBytes))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
+		req.Header.Set("Content-Type", "application/json")
 		// Set authentication based on auth type
 		if cfg.BearerToken != "" {
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -88,21 +89,21 @@ func Patch_players_playeridHandler(cfg *config.APIConfig) func(ctx context.Conte
 func CreatePatch_players_playeridTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("patch_players_playerId",
 		mcp.WithDescription("Update a player"),
-		mcp.WithString("playerId", mcp.Required(), mcp.Description("The unique identifier for the player.")),
-		mcp.WithString("backgroundTop", mcp.Description("Input parameter: RGBA color: top 50% of background. Default: rgba(0, 0, 0, .7)")),
+		mcp.WithString("playerId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the player.")),
+		mcp.WithString("backgroundTop", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color: top 50% of background. Default: rgba(0, 0, 0, .7)")),
 		mcp.WithBoolean("enableApi", mcp.Description("Input parameter: enable/disable player SDK access. Default: true")),
 		mcp.WithBoolean("enableControls", mcp.Description("Input parameter: enable/disable player controls. Default: true")),
 		mcp.WithBoolean("forceLoop", mcp.Description("Input parameter: enable/disable looping. Default: false")),
 		mcp.WithBoolean("hideTitle", mcp.Description("Input parameter: enable/disable title. Default: false")),
-		mcp.WithString("linkHover", mcp.Description("Input parameter: RGBA color for all controls when hovered. Default: rgba(255, 255, 255, 1)")),
-		mcp.WithString("trackUnplayed", mcp.Description("Input parameter: RGBA color playback bar: downloaded but unplayed (buffered) content. Default: rgba(255, 255, 255, .35)")),
-		mcp.WithString("trackPlayed", mcp.Description("Input parameter: RGBA color playback bar: played content. Default: rgba(88, 131, 255, .95)")),
-		mcp.WithString("trackBackground", mcp.Description("Input parameter: RGBA color playback bar: background. Default: rgba(255, 255, 255, .2)")),
-		mcp.WithString("backgroundBottom", mcp.Description("Input parameter: RGBA color: bottom 50% of background. Default: rgba(0, 0, 0, .7)")),
-		mcp.WithString("backgroundText", mcp.Description("Input parameter: RGBA color for title text. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("linkHover", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for all controls when hovered. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("trackUnplayed", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color playback bar: downloaded but unplayed (buffered) content. Default: rgba(255, 255, 255, .35)")),
+		mcp.WithString("trackPlayed", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color playback bar: played content. Default: rgba(88, 131, 255, .95)")),
+		mcp.WithString("trackBackground", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color playback bar: background. Default: rgba(255, 255, 255, .2)")),
+		mcp.WithString("backgroundBottom", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color: bottom 50% of background. Default: rgba(0, 0, 0, .7)")),
+		mcp.WithString("backgroundText", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for title text. Default: rgba(255, 255, 255, 1)")),
 		mcp.WithBoolean("forceAutoplay", mcp.Description("Input parameter: enable/disable player autoplay. Default: false")),
-		mcp.WithString("link", mcp.Description("Input parameter: RGBA color for all controls. Default: rgba(255, 255, 255, 1)")),
-		mcp.WithString("text", mcp.Description("Input parameter: RGBA color for timer text. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("link", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for all controls. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("text", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for timer text. Default: rgba(255, 255, 255, 1)")),
 	)
 
 	return models.Tool{
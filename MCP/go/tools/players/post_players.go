@@ -8,35 +8,43 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// post_playersArgs are the arguments of post_players.
+type post_playersArgs struct {
+	ForceAutoplay    *bool   `body:"forceAutoplay"`
+	Link             *string `body:"link"`
+	Text             *string `body:"text"`
+	BackgroundTop    *string `body:"backgroundTop"`
+	EnableApi        *bool   `body:"enableApi"`
+	EnableControls   *bool   `body:"enableControls"`
+	ForceLoop        *bool   `body:"forceLoop"`
+	HideTitle        *bool   `body:"hideTitle"`
+	LinkHover        *string `body:"linkHover"`
+	TrackUnplayed    *string `body:"trackUnplayed"`
+	TrackPlayed      *string `body:"trackPlayed"`
+	TrackBackground  *string `body:"trackBackground"`
+	BackgroundBottom *string `body:"backgroundBottom"`
+	BackgroundText   *string `body:"backgroundText"`
+}
+
 func Post_playersHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.PlayerCreationPayload
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
+		var args post_playersArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/players", cfg.BaseURL)
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+		url := bind.URL(cfg.BaseURL+"/players", args)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
@@ -47,7 +55,7 @@ func Post_playersHandler(cfg *config.APIConfig) func(ctx context.Context, reques
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -81,19 +89,19 @@ func CreatePost_playersTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_players",
 		mcp.WithDescription("Create a player"),
 		mcp.WithBoolean("forceAutoplay", mcp.Description("Input parameter: enable/disable player autoplay. Default: false")),
-		mcp.WithString("link", mcp.Description("Input parameter: RGBA color for all controls. Default: rgba(255, 255, 255, 1)")),
-		mcp.WithString("text", mcp.Description("Input parameter: RGBA color for timer text. Default: rgba(255, 255, 255, 1)")),
-		mcp.WithString("backgroundTop", mcp.Description("Input parameter: RGBA color: top 50% of background. Default: rgba(0, 0, 0, .7)")),
+		mcp.WithString("link", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for all controls. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("text", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for timer text. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("backgroundTop", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color: top 50% of background. Default: rgba(0, 0, 0, .7)")),
 		mcp.WithBoolean("enableApi", mcp.Description("Input parameter: enable/disable player SDK access. Default: true")),
 		mcp.WithBoolean("enableControls", mcp.Description("Input parameter: enable/disable player controls. Default: true")),
 		mcp.WithBoolean("forceLoop", mcp.Description("Input parameter: enable/disable looping. Default: false")),
 		mcp.WithBoolean("hideTitle", mcp.Description("Input parameter: enable/disable title. Default: false")),
-		mcp.WithString("linkHover", mcp.Description("Input parameter: RGBA color for all controls when hovered. Default: rgba(255, 255, 255, 1)")),
-		mcp.WithString("trackUnplayed", mcp.Description("Input parameter: RGBA color playback bar: downloaded but unplayed (buffered) content. Default: rgba(255, 255, 255, .35)")),
-		mcp.WithString("trackPlayed", mcp.Description("Input parameter: RGBA color playback bar: played content. Default: rgba(88, 131, 255, .95)")),
-		mcp.WithString("trackBackground", mcp.Description("Input parameter: RGBA color playback bar: background. Default: rgba(255, 255, 255, .2)")),
-		mcp.WithString("backgroundBottom", mcp.Description("Input parameter: RGBA color: bottom 50% of background. Default: rgba(0, 0, 0, .7)")),
-		mcp.WithString("backgroundText", mcp.Description("Input parameter: RGBA color for title text. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("linkHover", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for all controls when hovered. Default: rgba(255, 255, 255, 1)")),
+		mcp.WithString("trackUnplayed", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color playback bar: downloaded but unplayed (buffered) content. Default: rgba(255, 255, 255, .35)")),
+		mcp.WithString("trackPlayed", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color playback bar: played content. Default: rgba(88, 131, 255, .95)")),
+		mcp.WithString("trackBackground", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color playback bar: background. Default: rgba(255, 255, 255, .2)")),
+		mcp.WithString("backgroundBottom", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color: bottom 50% of background. Default: rgba(0, 0, 0, .7)")),
+		mcp.WithString("backgroundText", mcp.Pattern(validate.RGBA), mcp.Description("Input parameter: RGBA color for title text. Default: rgba(255, 255, 255, 1)")),
 	)
 
 	return models.Tool{
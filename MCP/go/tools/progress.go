@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Progress returns a function sending progress notifications for a tool
+// call, or doing nothing if the client didn't ask for them. The progress
+// value must increase with each notification; total is left out when 0.
+func Progress(ctx context.Context, request mcp.CallToolRequest) func(progress, total int, message string) {
+	mcpSrv := server.ServerFromContext(ctx)
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil || mcpSrv == nil {
+		return func(int, int, string) {}
+	}
+	token := request.Params.Meta.ProgressToken
+	return func(progress, total int, message string) {
+		params := map[string]any{
+			"progressToken": token,
+			"progress":      progress,
+			"message":       message,
+		}
+		if total > 0 {
+			params["total"] = total
+		}
+		mcpSrv.SendNotificationToClient(ctx, "notifications/progress", params)
+	}
+}
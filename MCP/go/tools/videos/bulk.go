@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/models"
+	calls "github.com/api-video/mcp-server/tools"
+	"github.com/api-video/mcp-server/validate"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxBulkVideos caps the videos one bulk call acts on, the most a filter
+// lists with fetchAll.
+const maxBulkVideos = 1000
+
+// maxBulkConcurrency caps the videos handled at once. Requests also wait for
+// the rate limiter of the HTTP client and retry throttled responses.
+const maxBulkConcurrency = 10
+
+// bulkArgs select the videos of a bulk tool, to embed in its arguments.
+type bulkArgs struct {
+	VideoIDs    []string    `arg:"videoIds"`
+	Filter      *bulkFilter `arg:"filter"`
+	Preview     bool        `arg:"preview"`
+	Concurrency int         `arg:"concurrency" default:"4"`
+}
+
+// bulkFilter holds the get_videos filters a bulk tool accepts.
+type bulkFilter struct {
+	Title        string            `json:"title,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Metadata     []models.Metadata `json:"metadata,omitempty"`
+	LiveStreamID string            `json:"liveStreamId,omitempty"`
+}
+
+// bulkVideo is a selected video. Title and tags are only known when the
+// videos were listed or read.
+type bulkVideo struct {
+	VideoID string   `json:"videoId"`
+	Title   string   `json:"title,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// bulkItem is the outcome for one video.
+type bulkItem struct {
+	VideoID string          `json:"videoId"`
+	Title   string          `json:"title,omitempty"`
+	Status  string          `json:"status"` // done, failed, cancelled or planned
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// withBulkSelection adds the arguments of bulkArgs to a bulk tool.
+func withBulkSelection() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray("videoIds", mcp.WithStringItems(mcp.Pattern(validate.ID)), mcp.MinItems(1), mcp.MaxItems(maxBulkVideos), mcp.Description("The videos to act on. Use either videoIds or filter."))(tool)
+		mcp.WithObject("filter", mcp.Properties(map[string]any{
+			"title":        map[string]any{"type": "string", "minLength": 1},
+			"description":  map[string]any{"type": "string", "minLength": 1},
+			"tags":         map[string]any{"type": "array", "items": map[string]any{"type": "string", "minLength": 1}},
+			"metadata":     map[string]any{"type": "array", "items": validate.MetadataItem()},
+			"liveStreamId": map[string]any{"type": "string", "pattern": validate.ID},
+		}), mcp.Description(fmt.Sprintf("Act on the videos get_videos returns for these filters: title, description, tags, metadata and liveStreamId. At least one is needed, and at most %d videos may match.", maxBulkVideos)))(tool)
+		mcp.WithBoolean("preview", mcp.Description("List the videos and the changes without making them."))(tool)
+		mcp.WithNumber("concurrency", mcp.Min(1), mcp.Max(maxBulkConcurrency), mcp.Description(fmt.Sprintf("How many videos are handled at once, at most %d. Default: 4", maxBulkConcurrency)))(tool)
+	}
+}
+
+// selectVideos returns the videos of a bulk call. Videos given by ID are only
+// read, to know their title and tags, with read set.
+func selectVideos(ctx context.Context, cfg *config.APIConfig, args bulkArgs, read bool) ([]bulkVideo, error) {
+	switch {
+	case args.VideoIDs != nil && args.Filter != nil:
+		return nil, fmt.Errorf("Pass either videoIds or filter, not both")
+	case args.VideoIDs == nil && args.Filter == nil:
+		return nil, fmt.Errorf("Pass the videos to act on as videoIds or filter")
+	case args.Filter != nil:
+		return listVideos(ctx, cfg, *args.Filter)
+	}
+
+	var videos []bulkVideo
+	for _, id := range args.VideoIDs {
+		if !slices.ContainsFunc(videos, func(v bulkVideo) bool { return v.VideoID == id }) {
+			videos = append(videos, bulkVideo{VideoID: id})
+		}
+	}
+	if len(videos) > maxBulkVideos {
+		return nil, fmt.Errorf("Too many videos: at most %d can be handled at once", maxBulkVideos)
+	}
+	if !read {
+		return videos, nil
+	}
+	for i, item := range runEach(ctx, args.Concurrency, videos, nil, func(ctx context.Context, video bulkVideo) (string, error) {
+		return calls.Call(ctx, cfg, Get_videoHandler, map[string]any{"videoId": video.VideoID})
+	}) {
+		if item.Status != "done" {
+			return nil, fmt.Errorf("Failed to read video %s: %s", item.VideoID, item.Error)
+		}
+		if err := json.Unmarshal(item.Result, &videos[i]); err != nil {
+			return nil, fmt.Errorf("Failed to read video %s: %v", item.VideoID, err)
+		}
+	}
+	return videos, nil
+}
+
+// listVideos returns the videos get_videos lists for filter.
+func listVideos(ctx context.Context, cfg *config.APIConfig, filter bulkFilter) ([]bulkVideo, error) {
+	var args map[string]any
+	raw, _ := json.Marshal(filter)
+	if err := json.Unmarshal(raw, &args); err != nil || len(args) == 0 {
+		// Not filtering would select the whole library
+		return nil, fmt.Errorf("filter needs at least one of title, description, tags, metadata or liveStreamId")
+	}
+	args["fetchAll"] = true
+	text, err := calls.Call(ctx, cfg, List_videosHandler, args)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Data       []bulkVideo `json:"data"`
+		NextCursor string      `json:"nextCursor"`
+	}
+	if err := json.Unmarshal([]byte(text), &list); err != nil {
+		return nil, fmt.Errorf("Failed to read the video list: %v", err)
+	}
+	if list.NextCursor != "" {
+		return nil, fmt.Errorf("The filter matches more than %d videos, narrow it down", maxBulkVideos)
+	}
+	return list.Data, nil
+}
+
+// runEach calls op for each video, concurrency at a time, and returns the
+// outcomes in the order of videos. Videos not started when ctx ends are
+// cancelled. With progress set, each finished video is reported.
+func runEach(ctx context.Context, concurrency int, videos []bulkVideo, progress func(progress, total int, message string), op func(ctx context.Context, video bulkVideo) (string, error)) []bulkItem {
+	items := make([]bulkItem, len(videos))
+	sem := make(chan struct{}, max(1, min(concurrency, maxBulkConcurrency)))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	finished := 0
+	for i, video := range videos {
+		items[i] = bulkItem{VideoID: video.VideoID, Title: video.Title}
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			items[i].Status, items[i].Error = "cancelled", ctx.Err().Error()
+			continue
+		}
+		wg.Add(1)
+		go func(item *bulkItem) {
+			defer func() { <-sem; wg.Done() }()
+			text, err := op(ctx, video)
+			if err != nil {
+				item.Status, item.Error = "failed", err.Error()
+			} else {
+				item.Status, item.Result = "done", resultJSON(text)
+			}
+			if progress != nil {
+				mu.Lock()
+				finished++
+				progress(finished, len(videos), item.VideoID+" "+item.Status)
+				mu.Unlock()
+			}
+		}(&items[i])
+	}
+	wg.Wait()
+	return items
+}
+
+// resultJSON returns a tool result text as JSON, quoted if it isn't JSON.
+func resultJSON(text string) json.RawMessage {
+	if json.Valid([]byte(text)) {
+		return json.RawMessage(text)
+	}
+	quoted, _ := json.Marshal(text)
+	return quoted
+}
+
+// bulkReport returns the outcomes of a bulk call with a count per status and
+// the fields of extra.
+func bulkReport(items []bulkItem, preview bool, extra map[string]any) (*mcp.CallToolResult, error) {
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Status]++
+	}
+	if items == nil {
+		items = []bulkItem{}
+	}
+	out := map[string]any{"preview": preview, "total": len(items), "counts": counts, "items": items}
+	for key, value := range extra {
+		out[key] = value
+	}
+	prettyJSON, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(prettyJSON)), nil
+}
+
+// planned returns the preview outcome of each video, with result set by
+// change when it isn't nil.
+func planned(videos []bulkVideo, change func(video bulkVideo) any) []bulkItem {
+	items := make([]bulkItem, len(videos))
+	for i, video := range videos {
+		items[i] = bulkItem{VideoID: video.VideoID, Title: video.Title, Status: "planned"}
+		if change != nil {
+			items[i].Result, _ = json.Marshal(change(video))
+		}
+	}
+	return items
+}
@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/api-video/mcp-server/bind"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/models"
+	calls "github.com/api-video/mcp-server/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// bulk_delete_videosArgs are the arguments of bulk_delete_videos.
+type bulk_delete_videosArgs struct {
+	bulkArgs
+}
+
+func Bulk_delete_videosHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args bulk_delete_videosArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// The preview confirms each video exists and shows its title
+		videos, err := selectVideos(ctx, cfg, args.bulkArgs, args.Preview)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if args.Preview {
+			return bulkReport(planned(videos, nil), true, nil)
+		}
+		items := runEach(ctx, args.Concurrency, videos, calls.Progress(ctx, request), func(ctx context.Context, video bulkVideo) (string, error) {
+			return calls.Call(ctx, cfg, Delete_videoHandler, map[string]any{"videoId": video.VideoID})
+		})
+		return bulkReport(items, false, nil)
+	}
+}
+
+func CreateBulk_delete_videosTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("bulk_delete_videos",
+		mcp.WithDescription("Delete many videos at once, selected by ID or by get_videos filters. Deleted videos can't be recovered. Returns the outcome for each video. Use preview first to check which videos are selected."),
+		withBulkSelection(),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Bulk_delete_videosHandler(cfg),
+	}
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/api-video/mcp-server/bind"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/models"
+	calls "github.com/api-video/mcp-server/tools"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// bulk_tag_videosArgs are the arguments of bulk_tag_videos.
+type bulk_tag_videosArgs struct {
+	bulkArgs
+	AddTags    []string `arg:"addTags"`
+	RemoveTags []string `arg:"removeTags"`
+}
+
+func Bulk_tag_videosHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args bulk_tag_videosArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if len(args.AddTags) == 0 && len(args.RemoveTags) == 0 {
+			return mcp.NewToolResultError("Nothing to change: pass addTags, removeTags or both"), nil
+		}
+
+		// The preview shows the tags each video would have
+		videos, err := selectVideos(ctx, cfg, args.bulkArgs, args.Preview)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		extra := map[string]any{"addTags": args.AddTags, "removeTags": args.RemoveTags}
+		if args.Preview {
+			return bulkReport(planned(videos, func(video bulkVideo) any {
+				return map[string]any{"tags": video.Tags, "newTags": editedTags(video.Tags, args.AddTags, args.RemoveTags)}
+			}), true, extra)
+		}
+		items := runEach(ctx, args.Concurrency, videos, calls.Progress(ctx, request), func(ctx context.Context, video bulkVideo) (string, error) {
+			// patch_videos_videoId reads the current tags of each video
+			return calls.Call(ctx, cfg, Patch_videoHandler, map[string]any{
+				"videoId":    video.VideoID,
+				"addTags":    args.AddTags,
+				"removeTags": args.RemoveTags,
+			})
+		})
+		return bulkReport(items, false, extra)
+	}
+}
+
+func CreateBulk_tag_videosTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("bulk_tag_videos",
+		mcp.WithDescription("Add and remove tags on many videos at once, selected by ID or by get_videos filters, keeping their other tags. Returns the outcome for each video. Use preview first to see the tags each video would have."),
+		withBulkSelection(),
+		mcp.WithArray("addTags", mcp.WithStringItems(mcp.MinLength(1)), mcp.Description("Tags to add to every video.")),
+		mcp.WithArray("removeTags", mcp.WithStringItems(), mcp.Description("Tags to remove from every video.")),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Bulk_tag_videosHandler(cfg),
+	}
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	"github.com/api-video/mcp-server/bind"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/models"
+	calls "github.com/api-video/mcp-server/tools"
+	"github.com/api-video/mcp-server/validate"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// bulk_update_videosArgs are the arguments of bulk_update_videos. The body
+// fields are sent to patch_videos_videoId for each video.
+type bulk_update_videosArgs struct {
+	bulkArgs
+	Title       *string       `body:"title"`
+	Description *string       `body:"description"`
+	Tags        []string      `body:"tags"`
+	Metadata    bind.Metadata `body:"metadata"`
+	Public      *bool         `body:"public"`
+	PlayerID    *string       `body:"playerId"`
+	MP4Support  *bool         `body:"mp4Support"`
+	Panoramic   *bool         `body:"panoramic"`
+}
+
+func Bulk_update_videosHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args bulk_update_videosArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		bodyBytes, err := bind.Body(args)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
+		}
+		var update map[string]any
+		if err := json.Unmarshal(bodyBytes, &update); err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
+		}
+		if len(update) == 0 {
+			return mcp.NewToolResultError("Nothing to update: pass at least one of title, description, tags, metadata, public, playerId, mp4Support or panoramic"), nil
+		}
+
+		videos, err := selectVideos(ctx, cfg, args.bulkArgs, false)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		extra := map[string]any{"update": update}
+		if args.Preview {
+			return bulkReport(planned(videos, nil), true, extra)
+		}
+		items := runEach(ctx, args.Concurrency, videos, calls.Progress(ctx, request), func(ctx context.Context, video bulkVideo) (string, error) {
+			patch := maps.Clone(update)
+			patch["videoId"] = video.VideoID
+			return calls.Call(ctx, cfg, Patch_videoHandler, patch)
+		})
+		return bulkReport(items, false, extra)
+	}
+}
+
+func CreateBulk_update_videosTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("bulk_update_videos",
+		mcp.WithDescription("Update many videos at once, selected by ID or by get_videos filters. Every video gets the same changes; arguments left out stay unchanged. Returns the outcome for each video. Use preview first to check which videos are selected."),
+		withBulkSelection(),
+		mcp.WithString("title", mcp.MinLength(1), mcp.Description("The title to give every video.")),
+		mcp.WithString("description", mcp.Description("The description to give every video.")),
+		mcp.WithArray("tags", mcp.WithStringItems(mcp.MinLength(1)), mcp.Description("The tags replacing those of every video. Use bulk_tag_videos to add or remove some tags.")),
+		mcp.WithArray("metadata", validate.MetadataItems(), mcp.Description("The metadata replacing that of every video.")),
+		mcp.WithBoolean("public", mcp.Description("Whether the videos can be viewed by everyone. False makes them private.")),
+		mcp.WithString("playerId", mcp.Pattern(validate.ID), mcp.Description("The player to assign to every video.")),
+		mcp.WithBoolean("mp4Support", mcp.Description("Whether an mp4 version is available.")),
+		mcp.WithBoolean("panoramic", mcp.Description("Whether the videos are 360 degree or immersive videos.")),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Bulk_update_videosHandler(cfg),
+	}
+}
@@ -6,56 +6,61 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	calls "github.com/api-video/mcp-server/tools"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// patch_videoArgs are the arguments of patch_videos_videoId.
+type patch_videoArgs struct {
+	VideoID     string        `path:"videoId"`
+	Tags        []string      `body:"tags"`
+	Title       *string       `body:"title"`
+	Description *string       `body:"description"`
+	Metadata    bind.Metadata `body:"metadata"`
+	MP4Support  *bool         `body:"mp4Support"`
+	Panoramic   *bool         `body:"panoramic"`
+	PlayerID    *string       `body:"playerId"`
+	Public      *bool         `body:"public"`
+	// Edits of the current lists, read before the update
+	AddTags        []string      `arg:"addTags"`
+	RemoveTags     []string      `arg:"removeTags"`
+	SetMetadata    bind.Metadata `arg:"setMetadata"`
+	RemoveMetadata []string      `arg:"removeMetadata"`
+}
+
 func Patch_videoHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		videoIdVal, ok := args["videoId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: videoId"), nil
+		var args patch_videoArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		videoId, ok := videoIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: videoId"), nil
+		if result := editLists(ctx, cfg, &args); result != nil {
+			return result, nil
 		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
-		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/videos/%s", cfg.BaseURL, videoId)
-		req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(bodyBytes))
-		req.Header.Set("Content-Type", "application/json")
+		url := bind.URL(cfg.BaseURL+"/videos/{videoId}", args)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(bodyBytes))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
+		req.Header.Set("Content-Type", "application/json")
 		// Set authentication based on auth type
 		if cfg.BearerToken != "" {
 			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.BearerToken))
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -85,18 +90,79 @@ func Patch_videoHandler(cfg *config.APIConfig) func(ctx context.Context, request
 	}
 }
 
+// editLists applies addTags, removeTags, setMetadata and removeMetadata to
+// the current tags and metadata of the video, setting the full lists to send.
+// api.video only replaces whole lists, so a concurrent update of the same
+// video between the read and the write is lost. It returns an error result
+// if the edits can't be applied.
+func editLists(ctx context.Context, cfg *config.APIConfig, args *patch_videoArgs) *mcp.CallToolResult {
+	editTags := args.AddTags != nil || args.RemoveTags != nil
+	editMetadata := args.SetMetadata != nil || args.RemoveMetadata != nil
+	switch {
+	case !editTags && !editMetadata:
+		return nil
+	case editTags && args.Tags != nil:
+		return mcp.NewToolResultError("tags replaces all the tags, it can't be combined with addTags or removeTags")
+	case editMetadata && args.Metadata != nil:
+		return mcp.NewToolResultError("metadata replaces all the metadata, it can't be combined with setMetadata or removeMetadata")
+	}
+
+	// Read through get_video so the read shares its request building and auth
+	text, err := calls.Call(ctx, cfg, Get_videoHandler, map[string]any{"videoId": args.VideoID})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	var current struct {
+		Tags     []string          `json:"tags"`
+		Metadata []models.Metadata `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(text), &current); err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to read the current video", err)
+	}
+
+	if editTags {
+		args.Tags = editedTags(current.Tags, args.AddTags, args.RemoveTags)
+	}
+	if editMetadata {
+		metadata := bind.Metadata{}
+		for _, pair := range current.Metadata {
+			if !slices.Contains(args.RemoveMetadata, pair.Key) && !slices.ContainsFunc(args.SetMetadata, func(set models.Metadata) bool { return set.Key == pair.Key }) {
+				metadata = append(metadata, pair)
+			}
+		}
+		args.Metadata = append(metadata, args.SetMetadata...)
+	}
+	return nil
+}
+
+// editedTags returns current with add appended and remove left out, without
+// duplicates.
+func editedTags(current, add, remove []string) []string {
+	tags := []string{}
+	for _, tag := range append(slices.Clip(current), add...) {
+		if !slices.Contains(remove, tag) && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 func CreatePatch_videoTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("patch_videos_videoId",
 		mcp.WithDescription("Update a video"),
-		mcp.WithString("videoId", mcp.Required(), mcp.Description("The video ID for the video you want to delete.")),
-		mcp.WithArray("tags", mcp.Description("Input parameter: A list of terms or words you want to tag the video with. Make sure the list includes all the tags you want as whatever you send in this list will overwrite the existing list for the video.")),
-		mcp.WithString("title", mcp.Description("Input parameter: The title you want to use for your video.")),
+		mcp.WithString("videoId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The video ID for the video you want to delete.")),
+		mcp.WithArray("tags", mcp.WithStringItems(mcp.MinLength(1)), mcp.Description("Input parameter: A list of terms or words you want to tag the video with. Make sure the list includes all the tags you want as whatever you send in this list will overwrite the existing list for the video. Use addTags and removeTags to change only some tags.")),
+		mcp.WithString("title", mcp.MinLength(1), mcp.Description("Input parameter: The title you want to use for your video.")),
 		mcp.WithString("description", mcp.Description("Input parameter: A brief description of the video.")),
-		mcp.WithArray("metadata", mcp.Description("Input parameter: A list (array) of dictionaries where each dictionary contains a key value pair that describes the video. As with tags, you must send the complete list of metadata you want as whatever you send here will overwrite the existing metadata for the video. [Dynamic Metadata](https://api.video/blog/endpoints/dynamic-metadata) allows you to define a key that allows any value pair.")),
+		mcp.WithArray("metadata", validate.MetadataItems(), mcp.Description("Input parameter: A list (array) of dictionaries where each dictionary contains a key value pair that describes the video. As with tags, you must send the complete list of metadata you want as whatever you send here will overwrite the existing metadata for the video. Use setMetadata and removeMetadata to change only some pairs. [Dynamic Metadata](https://api.video/blog/endpoints/dynamic-metadata) allows you to define a key that allows any value pair.")),
 		mcp.WithBoolean("mp4Support", mcp.Description("Input parameter: Whether the player supports the mp4 format.")),
 		mcp.WithBoolean("panoramic", mcp.Description("Input parameter: Whether the video is a 360 degree or immersive video.")),
-		mcp.WithString("playerId", mcp.Description("Input parameter: The unique ID for the player you want to associate with your video.")),
+		mcp.WithString("playerId", mcp.Pattern(validate.ID), mcp.Description("Input parameter: The unique ID for the player you want to associate with your video.")),
 		mcp.WithBoolean("public", mcp.Description("Input parameter: Whether the video is publicly available or not. False means it is set to private. Default is true. Tutorials on [private videos](https://api.video/blog/endpoints/private-videos).")),
+		mcp.WithArray("addTags", mcp.WithStringItems(mcp.MinLength(1)), mcp.Description("Tags to add to the current tags of the video. Can't be combined with tags.")),
+		mcp.WithArray("removeTags", mcp.WithStringItems(), mcp.Description("Tags to remove from the current tags of the video. Can't be combined with tags.")),
+		mcp.WithArray("setMetadata", validate.MetadataItems(), mcp.Description("Metadata pairs to add to the current metadata of the video, replacing the values of existing keys. Can't be combined with metadata.")),
+		mcp.WithArray("removeMetadata", mcp.WithStringItems(), mcp.Description("Keys of the metadata pairs to remove from the current metadata of the video. Can't be combined with metadata.")),
 	)
 
 	return models.Tool{
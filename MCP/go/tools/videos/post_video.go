@@ -8,35 +8,39 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// post_videoArgs are the arguments of post_videos.
+type post_videoArgs struct {
+	Tags        []string      `body:"tags"`
+	Description *string       `body:"description"`
+	Panoramic   *bool         `body:"panoramic"`
+	Public      *bool         `body:"public"`
+	PublishedAt *string       `body:"publishedAt"`
+	Source      *string       `body:"source"`
+	MP4Support  *bool         `body:"mp4Support"`
+	Title       string        `body:"title"`
+	Metadata    bind.Metadata `body:"metadata"`
+	PlayerID    *string       `body:"playerId"`
+}
+
 func Post_videoHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
+		var args post_videoArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/videos", cfg.BaseURL)
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+		url := bind.URL(cfg.BaseURL+"/videos", args)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
@@ -47,7 +51,7 @@ func Post_videoHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -80,16 +84,16 @@ func Post_videoHandler(cfg *config.APIConfig) func(ctx context.Context, request
 func CreatePost_videoTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_videos",
 		mcp.WithDescription("Create a video"),
-		mcp.WithArray("tags", mcp.Description("Input parameter: A list of tags you want to use to describe your video.")),
+		mcp.WithArray("tags", mcp.WithStringItems(mcp.MinLength(1)), mcp.Description("Input parameter: A list of tags you want to use to describe your video.")),
 		mcp.WithString("description", mcp.Description("Input parameter: A brief description of your video.")),
 		mcp.WithBoolean("panoramic", mcp.Description("Input parameter: Indicates if your video is a 360/immersive video.")),
 		mcp.WithBoolean("public", mcp.Description("Input parameter: Whether your video can be viewed by everyone, or requires authentication to see it. A setting of false will require a unique token for each view. Default is true. Tutorials on [private videos](https://api.video/blog/endpoints/private-videos).")),
-		mcp.WithString("publishedAt", mcp.Description("Input parameter: The API uses ISO-8601 format for time, and includes 3 places for milliseconds.")),
+		mcp.WithString("publishedAt", mcp.Pattern(validate.DateTime), mcp.Description("Input parameter: The API uses ISO-8601 format for time, and includes 3 places for milliseconds.")),
 		mcp.WithString("source", mcp.Description("Input parameter: If you add a video already on the web, this is where you enter the url for the video.")),
 		mcp.WithBoolean("mp4Support", mcp.Description("Input parameter: Enables mp4 version in addition to streamed version.")),
-		mcp.WithString("title", mcp.Required(), mcp.Description("Input parameter: The title of your new video.")),
-		mcp.WithArray("metadata", mcp.Description("Input parameter: A list of key value pairs that you use to provide metadata for your video. These pairs can be made dynamic, allowing you to segment your audience. Read more on [dynamic metadata](https://api.video/blog/endpoints/dynamic-metadata).")),
-		mcp.WithString("playerId", mcp.Description("Input parameter: The unique identification number for your video player.")),
+		mcp.WithString("title", mcp.Required(), mcp.MinLength(1), mcp.Description("Input parameter: The title of your new video.")),
+		mcp.WithArray("metadata", validate.MetadataItems(), mcp.Description("Input parameter: A list of key value pairs that you use to provide metadata for your video. These pairs can be made dynamic, allowing you to segment your audience. Read more on [dynamic metadata](https://api.video/blog/endpoints/dynamic-metadata).")),
+		mcp.WithString("playerId", mcp.Pattern(validate.ID), mcp.Description("Input parameter: The unique identification number for your video player.")),
 	)
 
 	return models.Tool{
@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/api-video/mcp-server/bind"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/dryrun"
+	"github.com/api-video/mcp-server/models"
+	calls "github.com/api-video/mcp-server/tools"
+	"github.com/api-video/mcp-server/validate"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// waitFirstPoll is the delay before the second status request, growing
+	// by waitBackoff up to waitMaxPoll.
+	waitFirstPoll = 2 * time.Second
+	waitMaxPoll   = 15 * time.Second
+	waitBackoff   = 1.5
+)
+
+// wait_for_videoArgs are the arguments of wait_for_video.
+type wait_for_videoArgs struct {
+	VideoID string `path:"videoId"`
+	Quality string `arg:"quality"`
+	Timeout int    `arg:"timeout" default:"300"`
+}
+
+// videoStatus is the part of a video status wait_for_video looks at.
+type videoStatus struct {
+	Ingest struct {
+		Status string `json:"status"`
+	} `json:"ingest"`
+	Encoding struct {
+		Playable  bool             `json:"playable"`
+		Qualities []models.Quality `json:"qualities"`
+	} `json:"encoding"`
+}
+
+// summary describes the status in one line, e.g. "ingest uploaded,
+// playable, 720p encoded, 1080p encoding".
+func (s videoStatus) summary() string {
+	parts := []string{"ingest " + s.Ingest.Status, "not playable"}
+	if s.Ingest.Status == "" {
+		parts[0] = "not uploaded"
+	}
+	if s.Encoding.Playable {
+		parts[1] = "playable"
+	}
+	for _, q := range s.Encoding.Qualities {
+		parts = append(parts, q.Quality+" "+q.Status)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// quality returns the status of one quality, empty until it is listed.
+func (s videoStatus) quality(name string) string {
+	for _, q := range s.Encoding.Qualities {
+		if q.Quality == name {
+			return q.Status
+		}
+	}
+	return ""
+}
+
+// failed reports whether encoding is over without making the video
+// playable: every quality is encoded or failed, and at least one failed.
+func (s videoStatus) failed() bool {
+	failed := false
+	for _, q := range s.Encoding.Qualities {
+		switch q.Status {
+		case "failed":
+			failed = true
+		case "encoded":
+		default:
+			return false
+		}
+	}
+	return failed
+}
+
+func Wait_for_videoHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args wait_for_videoArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		timeout := time.Duration(args.Timeout) * time.Second
+		deadline := time.Now().Add(timeout)
+		progress := calls.Progress(ctx, request)
+
+		wait := waitFirstPoll
+		for attempt := 1; ; attempt++ {
+			status, result := fetchVideoStatus(ctx, cfg, args.VideoID)
+			if result != nil {
+				return result, nil
+			}
+			// The progress value counts the status checks
+			progress(attempt, 0, status.summary())
+
+			switch target := status.quality(args.Quality); {
+			case args.Quality == "" && status.Encoding.Playable,
+				args.Quality != "" && target == "encoded":
+				return waitResult(args.VideoID, "ready", status)
+			case target == "failed":
+				return mcp.NewToolResultError(fmt.Sprintf("Encoding %s of video %s failed: %s", args.Quality, args.VideoID, status.summary())), nil
+			case args.Quality == "" && status.failed():
+				return mcp.NewToolResultError(fmt.Sprintf("Encoding of video %s failed: %s", args.VideoID, status.summary())), nil
+			}
+
+			if dryrun.Enabled(ctx) {
+				// The status never changes in a dry run
+				return waitResult(args.VideoID, "dryRun", status)
+			}
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return waitResult(args.VideoID, "timeout", status)
+			}
+			select {
+			case <-ctx.Done():
+				return mcp.NewToolResultError(fmt.Sprintf("Stopped waiting for video %s: %v", args.VideoID, ctx.Err())), nil
+			case <-time.After(min(wait, remaining)):
+			}
+			wait = min(time.Duration(float64(wait)*waitBackoff), waitMaxPoll)
+		}
+	}
+}
+
+// fetchVideoStatus reads the status of a video with the get_videos_videoId_status
+// handler.
+func fetchVideoStatus(ctx context.Context, cfg *config.APIConfig, videoID string) (videoStatus, *mcp.CallToolResult) {
+	text, err := calls.Call(ctx, cfg, Get_video_statusHandler, map[string]any{"videoId": videoID})
+	if err != nil {
+		return videoStatus{}, mcp.NewToolResultError(err.Error())
+	}
+	var status videoStatus
+	if err := json.Unmarshal([]byte(text), &status); err != nil {
+		return videoStatus{}, mcp.NewToolResultErrorFromErr("Failed to read the video status", err)
+	}
+	return status, nil
+}
+
+func waitResult(videoID, outcome string, status videoStatus) (*mcp.CallToolResult, error) {
+	prettyJSON, err := json.MarshalIndent(map[string]any{
+		"videoId": videoID,
+		"outcome": outcome,
+		"summary": status.summary(),
+		"status":  status,
+	}, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(prettyJSON)), nil
+}
+
+func CreateWait_for_videoTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("wait_for_video",
+		mcp.WithDescription("Wait until a video is playable, or until one of its qualities is encoded. Checks the video status with a growing interval and reports each status as progress. Returns outcome \"ready\", or \"timeout\" with the last status; call it again to keep waiting. Returns an error if the quality fails to encode or, without a quality, if encoding ends with a failed quality and the video not playable."),
+		mcp.WithString("videoId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the video to wait for.")),
+		mcp.WithString("quality", mcp.Enum("240p", "360p", "480p", "720p", "1080p", "2160p"), mcp.Description("Wait until this quality is encoded instead of until the video is playable.")),
+		mcp.WithNumber("timeout", mcp.Min(1), mcp.Max(1800), mcp.Description("Seconds to wait at most. Default: 300")),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Wait_for_videoHandler(cfg),
+	}
+}
@@ -7,27 +7,26 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// get_upload_tokens_uploadtokenArgs are the arguments of get_upload-tokens_uploadToken.
+type get_upload_tokens_uploadtokenArgs struct {
+	UploadToken string `path:"uploadToken"`
+}
+
 func Get_upload_tokens_uploadtokenHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		uploadTokenVal, ok := args["uploadToken"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: uploadToken"), nil
-		}
-		uploadToken, ok := uploadTokenVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: uploadToken"), nil
+		var args get_upload_tokens_uploadtokenArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		url := fmt.Sprintf("%s/upload-tokens/%s", cfg.BaseURL, uploadToken)
-		req, err := http.NewRequest("GET", url, nil)
+		url := bind.URL(cfg.BaseURL+"/upload-tokens/{uploadToken}", args)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -37,7 +36,7 @@ func Get_upload_tokens_uploadtokenHandler(cfg *config.APIConfig) func(ctx contex
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -70,7 +69,7 @@ func Get_upload_tokens_uploadtokenHandler(cfg *config.APIConfig) func(ctx contex
 func CreateGet_upload_tokens_uploadtokenTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_upload-tokens_uploadToken",
 		mcp.WithDescription("Show upload token"),
-		mcp.WithString("uploadToken", mcp.Required(), mcp.Description("The unique identifier for the token you want information about.")),
+		mcp.WithString("uploadToken", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique identifier for the token you want information about.")),
 	)
 
 	return models.Tool{
@@ -8,35 +8,29 @@ import (
 	"net/http"
 	"bytes"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// post_upload_tokensArgs are the arguments of post_upload-tokens.
+type post_upload_tokensArgs struct {
+	TTL *int `body:"ttl"`
+}
+
 func Post_upload_tokensHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		// Create properly typed request body using the generated schema
-		var requestBody models.GeneratedType
-		
-		// Optimized: Single marshal/unmarshal with JSON tags handling field mapping
-		if argsJSON, err := json.Marshal(args); err == nil {
-			if err := json.Unmarshal(argsJSON, &requestBody); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to convert arguments to request type: %v", err)), nil
-			}
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal arguments: %v", err)), nil
+		var args post_upload_tokensArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		
-		bodyBytes, err := json.Marshal(requestBody)
+		bodyBytes, err := bind.Body(args)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to encode request body", err), nil
 		}
-		url := fmt.Sprintf("%s/upload-tokens", cfg.BaseURL)
-		req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+		url := bind.URL(cfg.BaseURL+"/upload-tokens", args)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
@@ -47,7 +41,7 @@ func Post_upload_tokensHandler(cfg *config.APIConfig) func(ctx context.Context,
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -80,7 +74,7 @@ func Post_upload_tokensHandler(cfg *config.APIConfig) func(ctx context.Context,
 func CreatePost_upload_tokensTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_upload-tokens",
 		mcp.WithDescription("Generate an upload token"),
-		mcp.WithNumber("ttl", mcp.Description("Input parameter: Time in seconds that the token will be active. A value of 0 means that the token has no exipration date. The default is to have no expiration.")),
+		mcp.WithNumber("ttl", mcp.Min(0), mcp.Max(2147483647), mcp.Description("Input parameter: Time in seconds that the token will be active. A value of 0 means that the token has no exipration date. The default is to have no expiration.")),
 	)
 
 	return models.Tool{
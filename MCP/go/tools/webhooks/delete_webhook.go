@@ -7,27 +7,26 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// delete_webhookArgs are the arguments of delete_webhooks_webhookId.
+type delete_webhookArgs struct {
+	WebhookID string `path:"webhookId"`
+}
+
 func Delete_webhookHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		webhookIdVal, ok := args["webhookId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: webhookId"), nil
-		}
-		webhookId, ok := webhookIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: webhookId"), nil
+		var args delete_webhookArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		url := fmt.Sprintf("%s/webhooks/%s", cfg.BaseURL, webhookId)
-		req, err := http.NewRequest("DELETE", url, nil)
+		url := bind.URL(cfg.BaseURL+"/webhooks/{webhookId}", args)
+		req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -37,7 +36,7 @@ func Delete_webhookHandler(cfg *config.APIConfig) func(ctx context.Context, requ
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -70,7 +69,7 @@ func Delete_webhookHandler(cfg *config.APIConfig) func(ctx context.Context, requ
 func CreateDelete_webhookTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("delete_webhooks_webhookId",
 		mcp.WithDescription("Delete a Webhook"),
-		mcp.WithString("webhookId", mcp.Required(), mcp.Description("The webhook you wish to delete.")),
+		mcp.WithString("webhookId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The webhook you wish to delete.")),
 	)
 
 	return models.Tool{
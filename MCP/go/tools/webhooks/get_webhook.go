@@ -7,27 +7,26 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/api-video/mcp-server/bind"
 	"github.com/api-video/mcp-server/config"
 	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// get_webhookArgs are the arguments of get_webhooks_webhookId.
+type get_webhookArgs struct {
+	WebhookID string `path:"webhookId"`
+}
+
 func Get_webhookHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, ok := request.Params.Arguments.(map[string]any)
-		if !ok {
-			return mcp.NewToolResultError("Invalid arguments object"), nil
-		}
-		webhookIdVal, ok := args["webhookId"]
-		if !ok {
-			return mcp.NewToolResultError("Missing required path parameter: webhookId"), nil
-		}
-		webhookId, ok := webhookIdVal.(string)
-		if !ok {
-			return mcp.NewToolResultError("Invalid path parameter: webhookId"), nil
+		var args get_webhookArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		url := fmt.Sprintf("%s/webhooks/%s", cfg.BaseURL, webhookId)
-		req, err := http.NewRequest("GET", url, nil)
+		url := bind.URL(cfg.BaseURL+"/webhooks/{webhookId}", args)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -37,7 +36,7 @@ func Get_webhookHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := cfg.HTTPClient().Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -70,7 +69,7 @@ func Get_webhookHandler(cfg *config.APIConfig) func(ctx context.Context, request
 func CreateGet_webhookTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_webhooks_webhookId",
 		mcp.WithDescription("Show Webhook details"),
-		mcp.WithString("webhookId", mcp.Required(), mcp.Description("The unique webhook you wish to retreive details on.")),
+		mcp.WithString("webhookId", mcp.Required(), mcp.Pattern(validate.ID), mcp.Description("The unique webhook you wish to retreive details on.")),
 	)
 
 	return models.Tool{
@@ -0,0 +1,241 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/api-video/mcp-server/bind"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/models"
+	calls "github.com/api-video/mcp-server/tools"
+	tools_captions "github.com/api-video/mcp-server/tools/captions"
+	tools_videos "github.com/api-video/mcp-server/tools/videos"
+	"github.com/api-video/mcp-server/validate"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rollbackTimeout bounds the deletion of a video after a failed publication,
+// which runs even when the call was cancelled.
+const rollbackTimeout = 30 * time.Second
+
+// caption is a caption to upload with publish_video.
+type caption struct {
+	Language string `json:"language"`
+	Content  string `json:"content"`
+}
+
+// publish_videoArgs are the arguments of publish_video.
+type publish_videoArgs struct {
+	Title          string        `arg:"title"`
+	Description    *string       `arg:"description"`
+	Source         string        `arg:"source"`
+	Tags           []string      `arg:"tags"`
+	Metadata       bind.Metadata `arg:"metadata"`
+	Public         *bool         `arg:"public"`
+	MP4Support     *bool         `arg:"mp4Support"`
+	Captions       []caption     `arg:"captions"`
+	DefaultCaption string        `arg:"defaultCaption"`
+	PlayerID       string        `arg:"playerId"`
+	Timecode       string        `arg:"thumbnailTimecode"`
+	Timeout        int           `arg:"timeout" default:"300"`
+	Rollback       bool          `arg:"rollback"`
+}
+
+// step is the outcome of one step of the publication.
+type step struct {
+	Name   string          `json:"step"`
+	Status string          `json:"status"` // done, failed or skipped
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// publication runs the steps of one publish_video call and records their
+// outcomes.
+type publication struct {
+	ctx      context.Context
+	cfg      *config.APIConfig
+	steps    []step
+	failed   bool
+	progress func(progress, total int, message string)
+	total    int
+}
+
+// run runs one step with a tool handler, unless an earlier step failed. It
+// returns the text result of the handler and whether the step succeeded.
+func (p *publication) run(name string, newHandler calls.NewHandler, args map[string]any) (string, bool) {
+	if p.failed {
+		p.steps = append(p.steps, step{Name: name, Status: "skipped"})
+		return "", false
+	}
+	p.progress(len(p.steps), p.total, name)
+	text, err := calls.Call(p.ctx, p.cfg, newHandler, args)
+	if err != nil {
+		p.fail(name, err.Error())
+		return "", false
+	}
+	s := step{Name: name, Status: "done", Result: json.RawMessage(text)}
+	if !json.Valid([]byte(text)) {
+		s.Result, _ = json.Marshal(text)
+	}
+	p.steps = append(p.steps, s)
+	return text, true
+}
+
+func (p *publication) fail(name, message string) {
+	p.steps = append(p.steps, step{Name: name, Status: "failed", Error: message})
+	p.failed = true
+}
+
+// reject marks the last step failed, for a result that doesn't allow the
+// next steps.
+func (p *publication) reject(message string) {
+	last := &p.steps[len(p.steps)-1]
+	last.Status, last.Error, last.Result = "failed", message, nil
+	p.failed = true
+}
+
+func Publish_videoHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args publish_videoArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		languages := make([]string, len(args.Captions))
+		for i, c := range args.Captions {
+			languages[i] = c.Language
+		}
+		if args.DefaultCaption != "" && !slices.Contains(languages, args.DefaultCaption) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid parameter: defaultCaption %q is not one of the captions uploaded", args.DefaultCaption)), nil
+		}
+
+		p := &publication{
+			ctx:      metrics.WithOperation(ctx, "publish_video"),
+			cfg:      cfg,
+			progress: calls.Progress(ctx, request),
+			total:    1 + len(args.Captions),
+		}
+		if args.DefaultCaption != "" {
+			p.total++
+		}
+		if args.PlayerID != "" {
+			p.total++
+		}
+		if args.Timecode != "" {
+			p.total += 2
+		}
+
+		// The source URL is imported by api.video as soon as the video exists
+		create := map[string]any{"title": args.Title}
+		if args.Description != nil {
+			create["description"] = *args.Description
+		}
+		if args.Source != "" {
+			create["source"] = args.Source
+		}
+		if args.Tags != nil {
+			create["tags"] = args.Tags
+		}
+		if args.Metadata != nil {
+			create["metadata"] = args.Metadata
+		}
+		if args.Public != nil {
+			create["public"] = *args.Public
+		}
+		if args.MP4Support != nil {
+			create["mp4Support"] = *args.MP4Support
+		}
+		var video models.Video
+		if text, ok := p.run("create", tools_videos.Post_videoHandler, create); ok {
+			if err := json.Unmarshal([]byte(text), &video); err != nil || video.Videoid == "" {
+				p.reject("The response has no videoId")
+			}
+		}
+
+		for _, c := range args.Captions {
+			p.run("caption "+c.Language, tools_captions.Post_videos_videoid_captions_languageHandler, map[string]any{
+				"videoId": video.Videoid, "language": c.Language, "content": c.Content,
+			})
+		}
+		if args.DefaultCaption != "" {
+			p.run("defaultCaption", tools_captions.Patch_videos_videoid_captions_languageHandler, map[string]any{
+				"videoId": video.Videoid, "language": args.DefaultCaption, "default": true,
+			})
+		}
+		if args.PlayerID != "" {
+			p.run("player", tools_videos.Patch_videoHandler, map[string]any{
+				"videoId": video.Videoid, "playerId": args.PlayerID,
+			})
+		}
+		if args.Timecode != "" {
+			// A thumbnail can only be picked from an encoded video
+			text, ok := p.run("encoding", tools_videos.Wait_for_videoHandler, map[string]any{
+				"videoId": video.Videoid, "timeout": args.Timeout,
+			})
+			var wait struct {
+				Outcome string `json:"outcome"`
+			}
+			if ok && (json.Unmarshal([]byte(text), &wait) != nil || wait.Outcome != "ready") {
+				p.reject(fmt.Sprintf("The video wasn't playable after %d seconds", args.Timeout))
+			}
+			p.run("thumbnail", tools_videos.Patch_videos_videoid_thumbnailHandler, map[string]any{
+				"videoId": video.Videoid, "timecode": args.Timecode,
+			})
+		}
+
+		out := map[string]any{"videoId": video.Videoid, "published": !p.failed, "steps": p.steps}
+		if p.failed && args.Rollback && video.Videoid != "" {
+			// Delete the video even if the call was cancelled
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(p.ctx), rollbackTimeout)
+			defer cancel()
+			_, err := calls.Call(ctx, cfg, tools_videos.Delete_videoHandler, map[string]any{"videoId": video.Videoid})
+			out["rolledBack"] = err == nil
+			if err != nil {
+				out["rollbackError"] = err.Error()
+			}
+		}
+
+		prettyJSON, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+		}
+		if p.failed {
+			return mcp.NewToolResultError(string(prettyJSON)), nil
+		}
+		return mcp.NewToolResultText(string(prettyJSON)), nil
+	}
+}
+
+func CreatePublish_videoTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("publish_video",
+		mcp.WithDescription("Publish a video in one call: create it from a source URL, upload its captions, set the default caption, assign a player and pick a thumbnail. Steps run in this order and stop at the first failure; the result lists each step as done, failed or skipped. Set rollback to delete the video when a step fails."),
+		mcp.WithString("title", mcp.Required(), mcp.MinLength(1), mcp.Description("The title of the video.")),
+		mcp.WithString("description", mcp.Description("A brief description of the video.")),
+		mcp.WithString("source", mcp.Pattern(validate.URL), mcp.Description("The URL of the video file, imported by api.video.")),
+		mcp.WithArray("tags", mcp.WithStringItems(mcp.MinLength(1)), mcp.Description("A list of tags describing the video.")),
+		mcp.WithArray("metadata", validate.MetadataItems(), mcp.Description("A list of key value pairs describing the video.")),
+		mcp.WithBoolean("public", mcp.Description("Whether the video can be viewed by everyone. Default is true.")),
+		mcp.WithBoolean("mp4Support", mcp.Description("Enables an mp4 version in addition to the streamed version.")),
+		mcp.WithArray("captions", mcp.Items(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"language": map[string]any{"type": "string", "pattern": validate.Language},
+				"content":  map[string]any{"type": "string", "minLength": 1},
+			},
+			"required": []string{"language", "content"},
+		}), mcp.Description("Captions to upload, each a BCP 47 language and the caption in WebVTT format.")),
+		mcp.WithString("defaultCaption", mcp.Pattern(validate.Language), mcp.Description("The language of the caption to show by default, one of the captions uploaded.")),
+		mcp.WithString("playerId", mcp.Pattern(validate.ID), mcp.Description("The player to assign to the video.")),
+		mcp.WithString("thumbnailTimecode", mcp.Pattern(validate.Timecode), mcp.Description("The frame to use as thumbnail, such as 124 or 00:01:00.000. The thumbnail is picked once the video is playable.")),
+		mcp.WithNumber("timeout", mcp.Min(1), mcp.Max(1800), mcp.Description("Seconds to wait for the video to be playable before picking the thumbnail. Default: 300")),
+		mcp.WithBoolean("rollback", mcp.Description("Delete the created video if a step fails.")),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Publish_videoHandler(cfg),
+	}
+}
@@ -0,0 +1,320 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Patterns shared by the tool definitions.
+const (
+	// ID matches the identifiers api.video puts in paths, so they can't
+	// point to another endpoint.
+	ID = `^[A-Za-z0-9_-]+$`
+	// Language matches BCP 47 language tags such as en, pt-BR or zh-Hant.
+	Language = `^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`
+	// Period matches analytics periods: a day, ISO week, month, year or a
+	// range of days.
+	Period = `^(\d{4}(-\d{2}(-\d{2})?)?|\d{4}-W\d{2}|\d{4}-\d{2}-\d{2}/\d{4}-\d{2}-\d{2})$`
+	// RGBA matches the player colors, such as rgba(255, 255, 255, .35).
+	RGBA = `^rgba\(\s*\d{1,3}\s*,\s*\d{1,3}\s*,\s*\d{1,3}\s*,\s*(0|1|0?\.\d+|1\.0+)\s*\)$`
+	// DateTime matches ISO-8601 date-times with a time zone.
+	DateTime = `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:?\d{2})$`
+	// Timecode matches thumbnail timecodes: seconds, hh:mm:ss.ms or
+	// hh:mm:ss:frame.
+	Timecode = `^(\d+|\d{2}:\d{2}:\d{2}(\.\d{1,3}|:\d+)?)$`
+	// URL matches HTTP and HTTPS URLs.
+	URL = `^https?://\S+$`
+)
+
+// patternNames describe the shared patterns in error messages.
+var patternNames = map[string]string{
+	ID:       "an identifier made of letters, digits, - and _",
+	Language: "a BCP 47 language tag such as en or pt-BR",
+	Period:   "a period such as 2024-01-31, 2024-W05, 2024-01, 2024 or 2024-01-01/2024-01-15",
+	RGBA:     "an RGBA color such as rgba(255, 255, 255, .35)",
+	DateTime: "an ISO-8601 date-time such as 2024-01-31T12:00:00.000Z",
+	Timecode: "a timecode such as 124, 00:01:00.000 or 00:01:00:12",
+	URL:      "an http or https URL",
+}
+
+// WebhookEvents are the events webhooks can subscribe to.
+var WebhookEvents = []string{
+	"video.encoding.quality.completed",
+	"video.source.recorded",
+	"video.caption.generated",
+	"video.summary.generated",
+	"live-stream.broadcast.started",
+	"live-stream.broadcast.ended",
+}
+
+// FieldError is a problem with one argument.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) String() string {
+	return e.Field + ": " + e.Message
+}
+
+// Handler checks the arguments of each call against the input schema of
+// tool, and answers with the problems found instead of calling handler.
+// Arguments the schema doesn't declare are problems with rejectUnknown set,
+// otherwise they are dropped before handler sees them.
+func Handler(tool mcp.Tool, handler server.ToolHandlerFunc, rejectUnknown bool) server.ToolHandlerFunc {
+	v := newValidator(tool.InputSchema)
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if request.Params.Arguments == nil {
+			args, ok = map[string]any{}, true
+		}
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		if unknown := v.unknown(args); len(unknown) > 0 {
+			if rejectUnknown {
+				return mcp.NewToolResultError("Unknown arguments: " + strings.Join(unknown, ", ")), nil
+			}
+			args = maps.Clone(args)
+			for _, name := range unknown {
+				delete(args, name)
+			}
+			request.Params.Arguments = args
+		}
+		if problems := v.arguments(args); len(problems) > 0 {
+			lines := make([]string, len(problems))
+			for i, problem := range problems {
+				lines[i] = "- " + problem.String()
+			}
+			return mcp.NewToolResultError("Invalid arguments:\n" + strings.Join(lines, "\n")), nil
+		}
+		return handler(ctx, request)
+	}
+}
+
+// validator checks arguments against a JSON schema, with its patterns
+// compiled once. Only the keywords the tool definitions use are supported.
+type validator struct {
+	schema   map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+func newValidator(schema mcp.ToolInputSchema) *validator {
+	v := &validator{
+		schema:   map[string]any{"type": "object", "properties": schema.Properties, "required": schema.Required},
+		patterns: map[string]*regexp.Regexp{},
+	}
+	v.compile(v.schema)
+	return v
+}
+
+func (v *validator) compile(schema map[string]any) {
+	if pattern, ok := schema["pattern"].(string); ok {
+		if _, seen := v.patterns[pattern]; !seen {
+			// A broken pattern is a bug in a tool definition
+			v.patterns[pattern] = regexp.MustCompile(pattern)
+		}
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		v.compile(items)
+	}
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		for _, property := range properties {
+			if property, ok := property.(map[string]any); ok {
+				v.compile(property)
+			}
+		}
+	}
+}
+
+// unknown returns the sorted names of the arguments the schema doesn't
+// declare.
+func (v *validator) unknown(args map[string]any) []string {
+	properties, _ := v.schema["properties"].(map[string]any)
+	var unknown []string
+	for name := range args {
+		if _, ok := properties[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+func (v *validator) arguments(args map[string]any) []FieldError {
+	var problems []FieldError
+	v.object("", v.schema, args, &problems)
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Field < problems[j].Field })
+	return problems
+}
+
+func (v *validator) object(path string, schema map[string]any, value map[string]any, problems *[]FieldError) {
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range required(schema) {
+		if val, ok := value[name]; !ok || val == nil {
+			*problems = append(*problems, FieldError{join(path, name), "is required"})
+		}
+	}
+	for name, val := range value {
+		property, ok := properties[name].(map[string]any)
+		if !ok || val == nil {
+			continue
+		}
+		v.value(join(path, name), property, val, problems)
+	}
+}
+
+func (v *validator) value(field string, schema map[string]any, value any, problems *[]FieldError) {
+	fail := func(format string, a ...any) {
+		*problems = append(*problems, FieldError{field, fmt.Sprintf(format, a...)})
+	}
+
+	switch schema["type"] {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		if enum := enumValues(schema["enum"]); len(enum) > 0 && !slices.Contains(enum, s) {
+			fail("must be one of %s, got %q", strings.Join(enum, ", "), s)
+			return
+		}
+		if n, ok := number(schema["minLength"]); ok && float64(utf8.RuneCountInString(s)) < n {
+			if n == 1 {
+				fail("must not be empty")
+			} else {
+				fail("must be at least %g characters long", n)
+			}
+		}
+		if n, ok := number(schema["maxLength"]); ok && float64(utf8.RuneCountInString(s)) > n {
+			fail("must be at most %g characters long", n)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !v.patterns[pattern].MatchString(s) {
+			if name, ok := patternNames[pattern]; ok {
+				fail("must be %s, got %q", name, s)
+			} else {
+				fail("%q does not match the pattern %s", s, pattern)
+			}
+		}
+	case "number", "integer":
+		n, ok := value.(float64)
+		if !ok {
+			fail("must be a number")
+			return
+		}
+		if schema["type"] == "integer" && n != math.Trunc(n) {
+			fail("must be a whole number")
+		}
+		if min, ok := number(schema["minimum"]); ok && n < min {
+			fail("must be at least %g, got %g", min, n)
+		}
+		if max, ok := number(schema["maximum"]); ok && n > max {
+			fail("must be at most %g, got %g", max, n)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be true or false")
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if n, ok := number(schema["minItems"]); ok && float64(len(items)) < n {
+			fail("must have at least %g items", n)
+		}
+		if n, ok := number(schema["maxItems"]); ok && float64(len(items)) > n {
+			fail("must have at most %g items", n)
+		}
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			for i, item := range items {
+				v.value(fmt.Sprintf("%s[%d]", field, i), itemSchema, item, problems)
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		v.object(field, schema, obj, problems)
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// required returns the required properties of an object schema, which the
+// mcp-go options store as []string and decoded schemas as []any.
+func required(schema map[string]any) []string {
+	switch names := schema["required"].(type) {
+	case []string:
+		return names
+	case []any:
+		var out []string
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func enumValues(v any) []string {
+	switch values := v.(type) {
+	case []string:
+		return values
+	case []any:
+		var out []string
+		for _, val := range values {
+			out = append(out, fmt.Sprint(val))
+		}
+		return out
+	}
+	return nil
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// MetadataItem is the schema of a metadata key/value pair.
+func MetadataItem() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"key":   map[string]any{"type": "string", "minLength": 1},
+			"value": map[string]any{"type": "string"},
+		},
+		"required": []string{"key", "value"},
+	}
+}
+
+// MetadataItems describes the items of metadata arguments, key/value pairs.
+func MetadataItems() mcp.PropertyOption {
+	return mcp.Items(MetadataItem())
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// testTool declares an argument for each kind of constraint.
+var testTool = mcp.NewTool("test",
+	mcp.WithString("id", mcp.Required(), mcp.Pattern(ID)),
+	mcp.WithString("code", mcp.Pattern(`^[a-z]{3}$`)),
+	mcp.WithString("status", mcp.Enum("public", "private")),
+	mcp.WithString("title", mcp.MinLength(1), mcp.MaxLength(5)),
+	mcp.WithString("name", mcp.MinLength(3)),
+	mcp.WithNumber("count", mcp.Min(1), mcp.Max(10)),
+	mcp.WithNumber("ratio"),
+	mcp.WithBoolean("public"),
+	mcp.WithArray("tags", mcp.WithStringItems(mcp.MinLength(1)), mcp.MinItems(1), mcp.MaxItems(2)),
+	mcp.WithArray("metadata", MetadataItems()),
+	mcp.WithObject("player", mcp.Properties(map[string]any{
+		"theme": map[string]any{"type": "string", "enum": []string{"dark", "light"}},
+	}), mcp.Required()),
+)
+
+// integerTool declares an integer, which mcp-go has no option for.
+var integerTool = mcp.Tool{InputSchema: mcp.ToolInputSchema{
+	Type:       "object",
+	Properties: map[string]any{"page": map[string]any{"type": "integer", "minimum": 1}},
+}}
+
+func TestArguments(t *testing.T) {
+	valid := func(set map[string]any) map[string]any {
+		args := map[string]any{"id": "vi1", "player": map[string]any{}}
+		for name, value := range set {
+			args[name] = value
+		}
+		return args
+	}
+	tests := []struct {
+		name string
+		tool mcp.Tool
+		args map[string]any
+		want []string // problems, each as "field: message"
+	}{
+		{"valid", testTool, valid(map[string]any{
+			"code": "abc", "status": "public", "title": "hi", "name": "abc", "count": 10.0, "ratio": 0.5, "public": false,
+			"tags": []any{"a"}, "metadata": []any{map[string]any{"key": "k", "value": ""}}, "player": map[string]any{"theme": "dark"},
+		}), nil},
+		{"required", testTool, map[string]any{"id": nil}, []string{"id: is required", "player: is required"}},
+		{"null optional", testTool, valid(map[string]any{"count": nil}), nil},
+		{"type string", testTool, valid(map[string]any{"title": 1.0}), []string{"title: must be a string"}},
+		{"type number", testTool, valid(map[string]any{"count": "1"}), []string{"count: must be a number"}},
+		{"type boolean", testTool, valid(map[string]any{"public": "true"}), []string{"public: must be true or false"}},
+		{"type array", testTool, valid(map[string]any{"tags": "a"}), []string{"tags: must be an array"}},
+		{"type object", testTool, valid(map[string]any{"player": "p"}), []string{"player: must be an object"}},
+		{"shared pattern", testTool, valid(map[string]any{"id": "../account"}), []string{`id: must be an identifier made of letters, digits, - and _, got "../account"`}},
+		{"other pattern", testTool, valid(map[string]any{"code": "ABC"}), []string{`code: "ABC" does not match the pattern ^[a-z]{3}$`}},
+		{"enum", testTool, valid(map[string]any{"status": "hidden"}), []string{`status: must be one of public, private, got "hidden"`}},
+		{"empty", testTool, valid(map[string]any{"title": ""}), []string{"title: must not be empty"}},
+		{"minLength", testTool, valid(map[string]any{"name": "ab"}), []string{"name: must be at least 3 characters long"}},
+		{"maxLength", testTool, valid(map[string]any{"title": "héllo!"}), []string{"title: must be at most 5 characters long"}},
+		{"maxLength counts characters", testTool, valid(map[string]any{"title": "héllo"}), nil},
+		{"minimum", testTool, valid(map[string]any{"count": 0.0}), []string{"count: must be at least 1, got 0"}},
+		{"maximum", testTool, valid(map[string]any{"count": 11.0}), []string{"count: must be at most 10, got 11"}},
+		{"integer", integerTool, map[string]any{"page": 1.5}, []string{"page: must be a whole number"}},
+		{"integer minimum", integerTool, map[string]any{"page": 0.0}, []string{"page: must be at least 1, got 0"}},
+		{"minItems", testTool, valid(map[string]any{"tags": []any{}}), []string{"tags: must have at least 1 items"}},
+		{"maxItems", testTool, valid(map[string]any{"tags": []any{"a", "b", "c"}}), []string{"tags: must have at most 2 items"}},
+		{"items", testTool, valid(map[string]any{"tags": []any{"a", ""}}), []string{"tags[1]: must not be empty"}},
+		{"object items", testTool, valid(map[string]any{"metadata": []any{map[string]any{"key": ""}}}), []string{"metadata[0].key: must not be empty", "metadata[0].value: is required"}},
+		{"nested property", testTool, valid(map[string]any{"player": map[string]any{"theme": "blue"}}), []string{`player.theme: must be one of dark, light, got "blue"`}},
+		{"sorted by field", testTool, valid(map[string]any{"title": "", "count": 0.0}), []string{"count: must be at least 1, got 0", "title: must not be empty"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, problem := range newValidator(tt.tool.InputSchema).arguments(tt.args) {
+				got = append(got, problem.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("problems = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tool := mcp.NewTool("test", mcp.WithString("id", mcp.Required(), mcp.Pattern(ID)))
+	tests := []struct {
+		name          string
+		arguments     any
+		rejectUnknown bool
+		wantError     string // start of the error result, empty when the call goes through
+		wantArgs      int    // arguments the handler sees
+	}{
+		{"valid", map[string]any{"id": "vi1"}, true, "", 1},
+		{"invalid", map[string]any{"id": "a/b"}, false, "Invalid arguments:\n- id: must be", 0},
+		{"missing arguments", nil, false, "Invalid arguments:\n- id: is required", 0},
+		{"not an object", []any{"vi1"}, false, "Invalid arguments object", 0},
+		{"unknown rejected", map[string]any{"id": "vi1", "zz": 1.0, "aa": 1.0}, true, "Unknown arguments: aa, zz", 0},
+		{"unknown dropped", map[string]any{"id": "vi1", "zz": 1.0}, false, "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := -1
+			handler := Handler(tool, func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				seen = len(request.GetArguments())
+				return mcp.NewToolResultText("ok"), nil
+			}, tt.rejectUnknown)
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tt.arguments
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			switch {
+			case tt.wantError == "" && (result.IsError || seen != tt.wantArgs):
+				t.Errorf("result %q, handler saw %d arguments, want the call with %d", text, seen, tt.wantArgs)
+			case tt.wantError != "" && (!result.IsError || !strings.HasPrefix(text, tt.wantError) || seen != -1):
+				t.Errorf("result %q, want an error starting with %q and no call", text, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   []string
+		invalid []string
+	}{
+		{ID, []string{"vi4k0jvEUuaTdRAEjQ4Jfrgz", "li_1-a"}, []string{"", "../account", "vi1?x=1", "vi 1"}},
+		{Language, []string{"en", "pt-BR", "zh-Hant"}, []string{"e", "english", "en_US"}},
+		{Period, []string{"2024", "2024-01", "2024-01-31", "2024-W05", "2024-01-01/2024-01-15"}, []string{"2024-1", "last week", "2024-01-01/2024"}},
+		{RGBA, []string{"rgba(255, 255, 255, .35)", "rgba(0,0,0,1)", "rgba(0, 0, 0, 0.5)"}, []string{"rgb(0, 0, 0)", "#ffffff", "rgba(0, 0, 0, 2)"}},
+		{DateTime, []string{"2024-01-31T12:00:00.000Z", "2024-01-31T12:00+02:00"}, []string{"2024-01-31", "2024-01-31T12:00:00"}},
+		{Timecode, []string{"124", "00:01:00.000", "00:01:00:12"}, []string{"1:00", "00:01:00.0000", "-1"}},
+		{URL, []string{"https://example.com/video.mp4", "http://127.0.0.1:8080"}, []string{"ftp://example.com", "example.com", "https://"}},
+	}
+	for _, tt := range tests {
+		re := regexp.MustCompile(tt.pattern)
+		for _, s := range tt.valid {
+			if !re.MatchString(s) {
+				t.Errorf("%s doesn't match %q", patternNames[tt.pattern], s)
+			}
+		}
+		for _, s := range tt.invalid {
+			if re.MatchString(s) {
+				t.Errorf("%s matches %q", patternNames[tt.pattern], s)
+			}
+		}
+	}
+}
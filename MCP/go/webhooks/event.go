@@ -0,0 +1,85 @@
+package webhooks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/api-video/mcp-server/validate"
+)
+
+// Event is an api.video webhook delivery. Which fields are set depends on
+// Type:
+//   - video.encoding.quality.completed: VideoID, Encoding and Quality
+//   - video.source.recorded: VideoID and LiveStreamID
+//   - video.caption.generated: VideoID and Language
+//   - video.summary.generated: VideoID
+//   - live-stream.broadcast.started and live-stream.broadcast.ended:
+//     LiveStreamID
+type Event struct {
+	Type         string    `json:"type"`
+	EmittedAt    time.Time `json:"emittedAt"`
+	VideoID      string    `json:"videoId,omitempty"`
+	LiveStreamID string    `json:"liveStreamId,omitempty"`
+	Encoding     string    `json:"encoding,omitempty"`
+	Quality      string    `json:"quality,omitempty"`
+	Language     string    `json:"language,omitempty"`
+
+	// Payload is the delivery as received, including fields not listed above.
+	Payload json.RawMessage `json:"-"`
+}
+
+// required lists the fields each known event type must carry.
+var required = map[string][]string{
+	"video.encoding.quality.completed": {"videoId", "encoding", "quality"},
+	"video.source.recorded":            {"videoId"},
+	"video.caption.generated":          {"videoId"},
+	"video.summary.generated":          {"videoId"},
+	"live-stream.broadcast.started":    {"liveStreamId"},
+	"live-stream.broadcast.ended":      {"liveStreamId"},
+}
+
+// Parse decodes a delivery. Event types api.video adds later are accepted
+// with only their type and emission time checked.
+func Parse(body []byte) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		return Event{}, fmt.Errorf("invalid event payload: %w", err)
+	}
+	if e.Type == "" {
+		return Event{}, errors.New("event has no type")
+	}
+	if e.EmittedAt.IsZero() {
+		return Event{}, fmt.Errorf("%s event has no emittedAt", e.Type)
+	}
+	fields := map[string]string{"videoId": e.VideoID, "liveStreamId": e.LiveStreamID, "encoding": e.Encoding, "quality": e.Quality}
+	for _, field := range required[e.Type] {
+		if fields[field] == "" {
+			return Event{}, fmt.Errorf("%s event has no %s", e.Type, field)
+		}
+	}
+	e.Payload = slices.Clone(body)
+	return e, nil
+}
+
+// ID identifies an event across deliveries: api.video retries a delivery
+// that wasn't acknowledged with the same payload. The whole payload is
+// hashed, so events differing only in fields Event doesn't list keep
+// distinct IDs.
+func (e Event) ID() string {
+	sum := sha256.Sum256(e.Payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// typeLabel is the metrics label of an event type. Unknown types share one
+// label so deliveries can't create arbitrary series.
+func typeLabel(eventType string) string {
+	if slices.Contains(validate.WebhookEvents, eventType) {
+		return eventType
+	}
+	return "other"
+}
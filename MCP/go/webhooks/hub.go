@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Filter selects events. Empty fields match any event.
+type Filter struct {
+	Types        []string
+	VideoID      string
+	LiveStreamID string
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e Event) bool {
+	return (len(f.Types) == 0 || slices.Contains(f.Types, e.Type)) &&
+		(f.VideoID == "" || f.VideoID == e.VideoID) &&
+		(f.LiveStreamID == "" || f.LiveStreamID == e.LiveStreamID)
+}
+
+type subscriber struct {
+	filter Filter
+	fn     func(context.Context, Event)
+}
+
+// Hub hands received events to the subscribers whose filter they match.
+type Hub struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]subscriber
+}
+
+// NewHub returns a hub without subscribers.
+func NewHub() *Hub {
+	return &Hub{subs: map[int]subscriber{}}
+}
+
+// Subscribe calls fn with every event matching filter until the returned
+// function is called. fn runs on the request that delivered the event, so it
+// should hand slow work off.
+func (h *Hub) Subscribe(filter Filter, fn func(context.Context, Event)) (unsubscribe func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	id := h.next
+	h.next++
+	h.subs[id] = subscriber{filter: filter, fn: fn}
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs, id)
+	}
+}
+
+// Publish calls the subscribers matching e and returns how many there were.
+func (h *Hub) Publish(ctx context.Context, e Event) int {
+	h.mu.Lock()
+	var matched []subscriber
+	for _, sub := range h.subs {
+		if sub.filter.Match(e) {
+			matched = append(matched, sub)
+		}
+	}
+	h.mu.Unlock()
+	for _, sub := range matched {
+		sub.fn(ctx, e)
+	}
+	return len(matched)
+}
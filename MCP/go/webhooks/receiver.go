@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+)
+
+// maxPayload bounds the size of a delivery; api.video events are a few
+// hundred bytes.
+const maxPayload = 64 << 10
+
+// Receiver serves api.video webhook deliveries. Each delivery must be signed
+// by a known webhook and emitted within the accepted clock skew; events
+// already received are acknowledged without being published again. Accepted
+// events are published on the hub.
+type Receiver struct {
+	hub     *Hub
+	config  func() *config.Config
+	client  func() *http.Client
+	secrets *secrets
+	store   *store
+}
+
+// NewReceiver returns a receiver publishing on hub. The configuration is
+// read for each delivery, except for the store file which is opened once.
+func NewReceiver(hub *Hub, cfg func() *config.Config, client func() *http.Client) *Receiver {
+	return &Receiver{
+		hub:     hub,
+		config:  cfg,
+		client:  client,
+		secrets: &secrets{},
+		store:   newStore(cfg().Webhooks.StoreFile),
+	}
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		reject(w, req, http.StatusMethodNotAllowed, "method", "Method not allowed")
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPayload))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			reject(w, req, http.StatusRequestEntityTooLarge, "too_large", "Payload too large")
+			return
+		}
+		reject(w, req, http.StatusBadRequest, "unreadable", "Failed to read payload")
+		return
+	}
+
+	// Nothing in an unsigned payload is looked at
+	signature := req.Header.Get(SignatureHeader)
+	if signature == "" {
+		reject(w, req, http.StatusUnauthorized, "missing_signature", "Missing "+SignatureHeader+" header")
+		return
+	}
+	ok, err := r.verify(req.Context(), body, signature)
+	if err != nil {
+		// api.video retries the delivery later
+		reject(w, req, http.StatusServiceUnavailable, "no_secrets", "Signature secrets unavailable")
+		return
+	}
+	if !ok {
+		reject(w, req, http.StatusUnauthorized, "bad_signature", "Invalid signature")
+		return
+	}
+
+	event, err := Parse(body)
+	if err != nil {
+		reject(w, req, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+	maxSkew := r.config().Webhooks.MaxSkew
+	if skew := time.Since(event.EmittedAt); skew > maxSkew || skew < -maxSkew {
+		reject(w, req, http.StatusBadRequest, "stale", "emittedAt is outside the accepted clock skew")
+		return
+	}
+	first, err := r.store.add(event.ID(), event.EmittedAt.Add(maxSkew))
+	if err != nil {
+		reject(w, req, http.StatusServiceUnavailable, "store_full", err.Error())
+		return
+	}
+
+	log := slog.With("event_type", event.Type, "video_id", event.VideoID, "live_stream_id", event.LiveStreamID)
+	if !first {
+		// Acknowledge again so api.video stops retrying
+		metrics.WebhookEvents.WithLabelValues(typeLabel(event.Type), "duplicate").Inc()
+		log.DebugContext(req.Context(), "Duplicate webhook delivery ignored")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	metrics.WebhookEvents.WithLabelValues(typeLabel(event.Type), "dispatched").Inc()
+	n := r.hub.Publish(req.Context(), event)
+	log.InfoContext(req.Context(), "Webhook event received", "subscribers", n)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reject answers a delivery that isn't accepted and counts it by reason.
+func reject(w http.ResponseWriter, req *http.Request, status int, reason, message string) {
+	metrics.WebhookRejections.WithLabelValues(reason).Inc()
+	slog.WarnContext(req.Context(), "Rejected webhook delivery", "reason", reason, "status", status, "error", message)
+	http.Error(w, message, status)
+}
@@ -0,0 +1,285 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+)
+
+const testSecret = "s3cret"
+
+// newTestReceiver returns a receiver accepting deliveries signed with
+// testSecret, and a counter of the events it publishes.
+func newTestReceiver(t *testing.T, configure func(*config.Config)) (*Receiver, *atomic.Int32) {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Webhooks.Enabled = true
+	cfg.Webhooks.Secrets = []string{testSecret}
+	if configure != nil {
+		configure(cfg)
+	}
+	hub := NewHub()
+	published := new(atomic.Int32)
+	hub.Subscribe(Filter{}, func(context.Context, Event) { published.Add(1) })
+	r := NewReceiver(hub, func() *config.Config { return cfg }, func() *http.Client { return http.DefaultClient })
+	return r, published
+}
+
+func sign(secret, body string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(body))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func event(emittedAt time.Time) string {
+	return fmt.Sprintf(`{"type":"video.source.recorded","emittedAt":%q,"videoId":"vi1","liveStreamId":"li1"}`, emittedAt.Format(time.RFC3339Nano))
+}
+
+// deliver posts body signed with signature and returns the status code.
+func deliver(r *Receiver, method, body, signature string) int {
+	req := httptest.NewRequest(method, "/webhooks/apivideo", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestSignatures(t *testing.T) {
+	body := event(time.Now())
+	tests := []struct {
+		name      string
+		signature string
+		want      int
+	}{
+		{"valid", sign(testSecret, body), http.StatusNoContent},
+		{"wrong secret", sign("other", body), http.StatusUnauthorized},
+		{"other body", sign(testSecret, body+" "), http.StatusUnauthorized},
+		{"not hex", "zz" + sign(testSecret, body)[2:], http.StatusUnauthorized},
+		{"empty", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, published := newTestReceiver(t, nil)
+			if got := deliver(r, http.MethodPost, body, tt.signature); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+			if want := tt.want == http.StatusNoContent; (published.Load() == 1) != want {
+				t.Errorf("published %d events, want published = %v", published.Load(), want)
+			}
+		})
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	const maxSkew = 15 * time.Minute
+	tests := []struct {
+		name   string
+		offset time.Duration
+		want   int
+	}{
+		{"now", 0, http.StatusNoContent},
+		{"just inside -maxSkew", -maxSkew + time.Minute, http.StatusNoContent},
+		{"just inside +maxSkew", maxSkew - time.Minute, http.StatusNoContent},
+		{"past -maxSkew", -maxSkew - time.Minute, http.StatusBadRequest},
+		{"past +maxSkew", maxSkew + time.Minute, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestReceiver(t, func(cfg *config.Config) { cfg.Webhooks.MaxSkew = maxSkew })
+			body := event(time.Now().Add(tt.offset))
+			if got := deliver(r, http.MethodPost, body, sign(testSecret, body)); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name          string
+		emittedAgo    time.Duration
+		wantFirst     int
+		wantReplay    int
+		wantPublished int32
+	}{
+		{"inside the window", time.Minute, http.StatusNoContent, http.StatusNoContent, 1},
+		{"after the window", 20 * time.Minute, http.StatusBadRequest, http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, published := newTestReceiver(t, func(cfg *config.Config) { cfg.Webhooks.MaxSkew = 15 * time.Minute })
+			body := event(time.Now().Add(-tt.emittedAgo))
+			if got := deliver(r, http.MethodPost, body, sign(testSecret, body)); got != tt.wantFirst {
+				t.Errorf("first delivery status = %d, want %d", got, tt.wantFirst)
+			}
+			if got := deliver(r, http.MethodPost, body, sign(testSecret, body)); got != tt.wantReplay {
+				t.Errorf("replay status = %d, want %d", got, tt.wantReplay)
+			}
+			if got := published.Load(); got != tt.wantPublished {
+				t.Errorf("published %d events, want %d", got, tt.wantPublished)
+			}
+		})
+	}
+}
+
+func TestEventIDs(t *testing.T) {
+	emittedAt := time.Now().Format(time.RFC3339Nano)
+	tests := []struct {
+		name          string
+		first, second string
+		wantPublished int32
+	}{
+		{
+			"same payload",
+			fmt.Sprintf(`{"type":"video.summary.generated","emittedAt":%q,"videoId":"vi1"}`, emittedAt),
+			fmt.Sprintf(`{"type":"video.summary.generated","emittedAt":%q,"videoId":"vi1"}`, emittedAt),
+			1,
+		},
+		{
+			"unknown type, other fields",
+			fmt.Sprintf(`{"type":"video.chapters.generated","emittedAt":%q,"videoId":"vi1"}`, emittedAt),
+			fmt.Sprintf(`{"type":"video.chapters.generated","emittedAt":%q,"videoId":"vi2"}`, emittedAt),
+			2,
+		},
+		{
+			"known type, unlisted fields",
+			fmt.Sprintf(`{"type":"video.summary.generated","emittedAt":%q,"videoId":"vi1","summaryId":"su1"}`, emittedAt),
+			fmt.Sprintf(`{"type":"video.summary.generated","emittedAt":%q,"videoId":"vi1","summaryId":"su2"}`, emittedAt),
+			2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, published := newTestReceiver(t, nil)
+			for _, body := range []string{tt.first, tt.second} {
+				if got := deliver(r, http.MethodPost, body, sign(testSecret, body)); got != http.StatusNoContent {
+					t.Errorf("status = %d, want %d", got, http.StatusNoContent)
+				}
+			}
+			if got := published.Load(); got != tt.wantPublished {
+				t.Errorf("published %d events, want %d", got, tt.wantPublished)
+			}
+		})
+	}
+}
+
+func TestStatusMapping(t *testing.T) {
+	valid := event(time.Now())
+	tests := []struct {
+		name      string
+		configure func(*config.Config)
+		prepare   func(*Receiver)
+		method    string
+		body      string
+		signature string
+		want      int
+	}{
+		{name: "accepted", body: valid, want: http.StatusNoContent},
+		{name: "method", method: http.MethodGet, want: http.StatusMethodNotAllowed},
+		{name: "too large", body: strings.Repeat(" ", maxPayload+1), want: http.StatusRequestEntityTooLarge},
+		{name: "missing signature", body: valid, signature: "-", want: http.StatusUnauthorized},
+		{name: "bad signature", body: valid, signature: sign("other", valid), want: http.StatusUnauthorized},
+		{
+			name:      "no secrets",
+			configure: func(cfg *config.Config) { cfg.Webhooks.Secrets = nil },
+			body:      valid,
+			want:      http.StatusServiceUnavailable,
+		},
+		{
+			name: "secrets unreachable",
+			configure: func(cfg *config.Config) {
+				cfg.Webhooks.Secrets = nil
+				cfg.API = config.APISection{BaseURL: "http://127.0.0.1:1", APIKey: "key"}
+			},
+			body: valid,
+			want: http.StatusServiceUnavailable,
+		},
+		{name: "malformed", body: `{"type":"video.source.recorded"}`, want: http.StatusBadRequest},
+		{name: "stale", body: event(time.Now().Add(-time.Hour)), want: http.StatusBadRequest},
+		{
+			name: "store full",
+			prepare: func(r *Receiver) {
+				for i := range maxSeen {
+					r.store.add(fmt.Sprint(i), time.Now().Add(time.Hour))
+				}
+			},
+			body: valid,
+			want: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestReceiver(t, tt.configure)
+			if tt.prepare != nil {
+				tt.prepare(r)
+			}
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			signature := tt.signature
+			switch signature {
+			case "":
+				signature = sign(testSecret, tt.body)
+			case "-":
+				signature = ""
+			}
+			if got := deliver(r, method, tt.body, signature); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchedSecrets(t *testing.T) {
+	var requests atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintf(w, `{"data":[{"webhookId":"wh1","url":"https://mcp.example/webhooks/apivideo","signatureSecret":%q},{"webhookId":"wh2","url":"https://mcp.example/other","signatureSecret":"other"}],"pagination":{"pagesTotal":1}}`, testSecret)
+	}))
+	defer api.Close()
+
+	r, published := newTestReceiver(t, func(cfg *config.Config) {
+		cfg.Webhooks.Secrets = nil
+		cfg.API = config.APISection{BaseURL: api.URL, APIKey: "key"}
+	})
+	// Concurrent deliveries share one fetch of the registered webhooks
+	const deliveries = 10
+	statuses := make(chan int, deliveries)
+	for i := range deliveries {
+		go func() {
+			body := event(time.Now().Add(-time.Duration(i) * time.Second))
+			statuses <- deliver(r, http.MethodPost, body, sign(testSecret, body))
+		}()
+	}
+	for range deliveries {
+		if got := <-statuses; got != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", got, http.StatusNoContent)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("fetched the webhooks %d times, want 1", got)
+	}
+	if got := published.Load(); got != deliveries {
+		t.Errorf("published %d events, want %d", got, deliveries)
+	}
+
+	// A webhook sending elsewhere doesn't authenticate deliveries
+	body := event(time.Now())
+	if got := deliver(r, http.MethodPost, body, sign("other", body)); got != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", got, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,202 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/metrics"
+	"github.com/api-video/mcp-server/models"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of a delivery's body,
+	// keyed with the signature secret of the webhook it was sent for.
+	SignatureHeader = "X-Api-Video-WebhookSignature"
+	// refreshInterval limits how often a signature matching no known secret
+	// makes the receiver fetch the registered webhooks again.
+	refreshInterval = time.Minute
+	// maxWebhookPages bounds the webhook list read, 100 per page.
+	maxWebhookPages = 10
+)
+
+// errNoSecrets means no secret could be loaded to check signatures with.
+var errNoSecrets = errors.New("no webhook signature secret is available")
+
+// secrets caches the signature secrets of the webhooks registered for the
+// receiver's path.
+type secrets struct {
+	mu       sync.Mutex
+	known    []string
+	err      error // of the last fetch
+	fetched  time.Time
+	fetching chan struct{} // closed when the fetch in progress ends
+}
+
+// verify reports whether signature is the HMAC of body with a configured
+// secret or the secret of a registered webhook. The registered webhooks are
+// fetched again when no secret matches, at most once per refreshInterval, so
+// webhooks registered after startup are accepted. An error means the
+// secrets couldn't be loaded and the delivery should be retried later.
+func (r *Receiver) verify(ctx context.Context, body []byte, signature string) (bool, error) {
+	cfg := r.config()
+	mac, err := hex.DecodeString(signature)
+	if err != nil {
+		return false, nil
+	}
+	if signedWith(body, mac, cfg.Webhooks.Secrets) {
+		return true, nil
+	}
+
+	r.secrets.mu.Lock()
+	known := r.secrets.known
+	r.secrets.mu.Unlock()
+	if signedWith(body, mac, known) {
+		return true, nil
+	}
+	known, err = r.secrets.refresh(ctx, func(ctx context.Context) ([]string, error) {
+		return fetchSecrets(ctx, cfg, r.client())
+	})
+	if signedWith(body, mac, known) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(known) == 0 && len(cfg.Webhooks.Secrets) == 0 {
+		return false, errNoSecrets
+	}
+	return false, nil
+}
+
+// refresh fetches the secrets again unless the last fetch is less than
+// refreshInterval old, and returns the known secrets with the error of the
+// last fetch. Concurrent deliveries wait for the same fetch, which runs
+// without the lock held.
+func (s *secrets) refresh(ctx context.Context, fetch func(context.Context) ([]string, error)) ([]string, error) {
+	s.mu.Lock()
+	done := s.fetching
+	if done == nil && time.Since(s.fetched) >= refreshInterval {
+		done = make(chan struct{})
+		s.fetching = done
+		// The result is shared, so the fetch doesn't end with this delivery
+		go s.fetch(context.WithoutCancel(ctx), fetch, done)
+	}
+	s.mu.Unlock()
+
+	if done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.known, s.err
+}
+
+func (s *secrets) fetch(ctx context.Context, fetch func(context.Context) ([]string, error), done chan struct{}) {
+	known, err := fetch(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to fetch webhook signature secrets", "error", err)
+	} else {
+		slog.DebugContext(ctx, "Fetched webhook signature secrets", "webhooks", len(known))
+	}
+
+	s.mu.Lock()
+	s.err, s.fetched, s.fetching = err, time.Now(), nil
+	if err == nil {
+		s.known = known
+	}
+	s.mu.Unlock()
+	close(done)
+}
+
+// signedWith reports whether mac is the HMAC-SHA256 of body with one of
+// keys.
+func signedWith(body, mac []byte, keys []string) bool {
+	for _, key := range keys {
+		h := hmac.New(sha256.New, []byte(key))
+		h.Write(body)
+		if hmac.Equal(mac, h.Sum(nil)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchSecrets lists the webhooks registered with the credentials of the
+// webhooks profile and returns the secrets of those sending to the
+// receiver's path.
+func fetchSecrets(ctx context.Context, cfg *config.Config, client *http.Client) ([]string, error) {
+	apiCfg, ok := cfg.ProfileConfig(cfg.Webhooks.Profile)
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q", cfg.Webhooks.Profile)
+	}
+	if apiCfg.BaseURL == "" || (apiCfg.BearerToken == "" && apiCfg.BasicAuth == "" && apiCfg.APIKey == "") {
+		return nil, nil
+	}
+	ctx = metrics.WithOperation(ctx, "webhooks:secrets")
+
+	var found []string
+	for page := 1; page <= maxWebhookPages; page++ {
+		var list struct {
+			Data       []models.Webhook `json:"data"`
+			Pagination struct {
+				PagesTotal int `json:"pagesTotal"`
+			} `json:"pagination"`
+		}
+		if err := getJSON(ctx, client, apiCfg, fmt.Sprintf("/webhooks?pageSize=100&currentPage=%d", page), &list); err != nil {
+			return nil, err
+		}
+		for _, webhook := range list.Data {
+			if u, err := url.Parse(webhook.Url); err == nil && u.Path == cfg.Webhooks.Path && webhook.Signaturesecret != "" {
+				found = append(found, webhook.Signaturesecret)
+			}
+		}
+		if page >= list.Pagination.PagesTotal {
+			break
+		}
+	}
+	return found, nil
+}
+
+// getJSON sends a GET request with the configured credentials and decodes
+// the response into v.
+func getJSON(ctx context.Context, client *http.Client, apiCfg *config.APIConfig, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiCfg.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case apiCfg.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+apiCfg.BearerToken)
+	case apiCfg.BasicAuth != "":
+		req.Header.Set("Authorization", "Basic "+apiCfg.BasicAuth)
+	case apiCfg.APIKey != "":
+		req.SetBasicAuth(apiCfg.APIKey, "")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s returned %d: %s", req.URL.Path, resp.StatusCode, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,284 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/api-video/mcp-server/auth"
+	"github.com/api-video/mcp-server/bind"
+	"github.com/api-video/mcp-server/config"
+	"github.com/api-video/mcp-server/models"
+	"github.com/api-video/mcp-server/resources"
+	"github.com/api-video/mcp-server/validate"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// EventMethod is the notification carrying a webhook event to a session.
+	EventMethod = "notifications/apivideo/event"
+	// maxSessionSubscriptions bounds the event subscriptions of one session.
+	maxSessionSubscriptions = 20
+	// orphanTimeout drops the subscriptions of sessions that have had no
+	// notification stream for that long.
+	orphanTimeout = 5 * time.Minute
+)
+
+// Sessions forwards the events published on a hub to the MCP sessions that
+// subscribed to them with subscribe_events. Notifications go through subs,
+// which knows the server holding each session's stream. Events belong to the
+// account of the webhooks profile, so only sessions allowed to act for it
+// can subscribe.
+type Sessions struct {
+	hub    *Hub
+	subs   *resources.Subscriptions
+	config func() *config.Config
+
+	mu       sync.Mutex
+	sessions map[string]map[string]*eventSubscription // session ID → subscription ID → subscription
+}
+
+// eventSubscription is one subscribe_events call, with the credentials and
+// client certificate it was made with.
+type eventSubscription struct {
+	delivery     string // notification or resourceUpdated
+	apiCfg       *config.APIConfig
+	identity     *auth.Identity
+	unsubscribe  func()
+	lastAttached time.Time
+}
+
+// NewSessions returns a registry without subscriptions. Who may subscribe is
+// checked against the current configuration on every call and delivery.
+func NewSessions(hub *Hub, subs *resources.Subscriptions, cfg func() *config.Config) *Sessions {
+	return &Sessions{hub: hub, subs: subs, config: cfg, sessions: map[string]map[string]*eventSubscription{}}
+}
+
+// subscribe_eventsArgs are the arguments of subscribe_events.
+type subscribe_eventsArgs struct {
+	Types        []string `arg:"types"`
+	VideoID      string   `arg:"videoId"`
+	LiveStreamID string   `arg:"liveStreamId"`
+	Delivery     string   `arg:"delivery" default:"notification"`
+}
+
+// unsubscribe_eventsArgs are the arguments of unsubscribe_events.
+type unsubscribe_eventsArgs struct {
+	SubscriptionID string `arg:"subscriptionId"`
+}
+
+// Tools returns subscribe_events and unsubscribe_events for a session calling
+// api.video with cfg.
+func (s *Sessions) Tools(cfg *config.APIConfig) []models.Tool {
+	subscribe := mcp.NewTool("subscribe_events",
+		mcp.WithDescription("Get api.video webhook events pushed to this session as they are received, instead of polling. "+
+			"The account needs a webhook registered with post_webhooks for the events, sending to this server's webhook URL. "+
+			"With delivery notification (default) each matching event is sent as a "+EventMethod+" notification with the subscriptionId and the event payload, such as "+
+			`{"type": "live-stream.broadcast.started", "emittedAt": "...", "liveStreamId": "li..."}. `+
+			"With delivery resourceUpdated a notifications/resources/updated is sent for apivideo://videos/{videoId}/status, or apivideo://live-streams/{liveStreamId} for live stream events; read the resource to see the change. "+
+			"Filters left out match every event. Only available to sessions using the server's webhook account credentials. Returns the subscriptionId to pass to unsubscribe_events."),
+		mcp.WithArray("types", mcp.WithStringEnumItems(validate.WebhookEvents), mcp.MinItems(1), mcp.Description("Event types to receive, e.g. live-stream.broadcast.started")),
+		mcp.WithString("videoId", mcp.Pattern(validate.ID), mcp.Description("Only events about this video")),
+		mcp.WithString("liveStreamId", mcp.Pattern(validate.ID), mcp.Description("Only events about this live stream, including video.source.recorded for its recordings")),
+		mcp.WithString("delivery", mcp.Enum("notification", "resourceUpdated"), mcp.Description("How events are sent. Default: notification")),
+	)
+	unsubscribe := mcp.NewTool("unsubscribe_events",
+		mcp.WithDescription("Stop an event subscription made with subscribe_events, or all of this session's subscriptions when subscriptionId is left out."),
+		mcp.WithString("subscriptionId", mcp.Description("The subscriptionId returned by subscribe_events")),
+	)
+	return []models.Tool{
+		{Definition: subscribe, Handler: s.subscribeHandler(cfg)},
+		{Definition: unsubscribe, Handler: s.unsubscribeHandler},
+	}
+}
+
+func (s *Sessions) subscribeHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args subscribe_eventsArgs
+		if err := bind.Arguments(request, &args); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		sessionID := sessionIDFrom(ctx)
+		if sessionID == "" {
+			return mcp.NewToolResultError("subscribe_events needs an MCP session: send the Mcp-Session-Id header"), nil
+		}
+		identity, _ := auth.FromContext(ctx)
+		if !s.allowed(cfg, identity) {
+			return mcp.NewToolResultError("Not allowed: events are only sent to sessions using the credentials of the webhooks profile or a client certificate matching webhooks.allowedClients"), nil
+		}
+		sub := &eventSubscription{delivery: args.Delivery, apiCfg: cfg, identity: identity}
+		filter := Filter{Types: args.Types, VideoID: args.VideoID, LiveStreamID: args.LiveStreamID}
+		id, err := s.subscribe(sessionID, filter, sub)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		out := map[string]any{"subscriptionId": id, "delivery": args.Delivery}
+		if args.Delivery == "notification" {
+			out["method"] = EventMethod
+		}
+		if !s.subs.Attached(sessionID) {
+			out["warning"] = "This session has no notification stream yet: open GET /mcp with the same Mcp-Session-Id to receive events."
+		}
+		return resultJSON(out)
+	}
+}
+
+func (s *Sessions) unsubscribeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args unsubscribe_eventsArgs
+	if err := bind.Arguments(request, &args); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sessionID := sessionIDFrom(ctx)
+	if sessionID == "" {
+		return mcp.NewToolResultError("unsubscribe_events needs an MCP session: send the Mcp-Session-Id header"), nil
+	}
+	removed := s.unsubscribe(sessionID, args.SubscriptionID)
+	if args.SubscriptionID != "" && removed == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No subscription %q in this session", args.SubscriptionID)), nil
+	}
+	return resultJSON(map[string]any{"unsubscribed": removed})
+}
+
+func sessionIDFrom(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+func resultJSON(v any) (*mcp.CallToolResult, error) {
+	prettyJSON, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(prettyJSON)), nil
+}
+
+// allowed reports whether a session may receive the events of the webhooks
+// profile's account: its verified client certificate matches
+// webhooks.allowedClients, or it calls api.video with the profile's base URL
+// and credentials.
+func (s *Sessions) allowed(apiCfg *config.APIConfig, identity *auth.Identity) bool {
+	cfg := s.config()
+	if identity != nil && len(cfg.Webhooks.AllowedClients) > 0 && identity.Allowed(cfg.Webhooks.AllowedClients) {
+		return true
+	}
+	account, ok := cfg.ProfileConfig(cfg.Webhooks.Profile)
+	if !ok || apiCfg == nil || apiCfg.BaseURL != account.BaseURL {
+		return false
+	}
+	matched := false
+	for _, credential := range [][2]string{
+		{apiCfg.APIKey, account.APIKey},
+		{apiCfg.BearerToken, account.BearerToken},
+		{apiCfg.BasicAuth, account.BasicAuth},
+	} {
+		if credential[0] == "" {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(credential[0]), []byte(credential[1])) != 1 {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// subscribe registers a subscription of the session and returns its ID.
+func (s *Sessions) subscribe(sessionID string, filter Filter, sub *eventSubscription) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropOrphans()
+	subscriptions := s.sessions[sessionID]
+	if len(subscriptions) >= maxSessionSubscriptions {
+		return "", fmt.Errorf("at most %d event subscriptions per session", maxSessionSubscriptions)
+	}
+	if subscriptions == nil {
+		subscriptions = map[string]*eventSubscription{}
+		s.sessions[sessionID] = subscriptions
+	}
+	id := uuid.NewString()
+	sub.lastAttached = time.Now()
+	sub.unsubscribe = s.hub.Subscribe(filter, func(ctx context.Context, e Event) {
+		s.deliver(ctx, sessionID, id, sub, e)
+	})
+	subscriptions[id] = sub
+	return id, nil
+}
+
+// unsubscribe removes one subscription of the session, or all of them when
+// id is empty, and returns how many were removed.
+func (s *Sessions) unsubscribe(sessionID, id string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for subID, sub := range s.sessions[sessionID] {
+		if id == "" || subID == id {
+			sub.unsubscribe()
+			delete(s.sessions[sessionID], subID)
+			removed++
+		}
+	}
+	if len(s.sessions[sessionID]) == 0 {
+		delete(s.sessions, sessionID)
+	}
+	return removed
+}
+
+// dropOrphans removes the subscriptions of sessions without a notification
+// stream for orphanTimeout. s.mu must be held.
+func (s *Sessions) dropOrphans() {
+	for sessionID, subscriptions := range s.sessions {
+		attached := s.subs.Attached(sessionID)
+		for id, sub := range subscriptions {
+			if attached {
+				sub.lastAttached = time.Now()
+			} else if time.Since(sub.lastAttached) > orphanTimeout {
+				sub.unsubscribe()
+				delete(subscriptions, id)
+			}
+		}
+		if len(subscriptions) == 0 {
+			delete(s.sessions, sessionID)
+		}
+	}
+}
+
+// deliver sends an event to a subscribed session, unless a reload changed
+// the webhooks account so that the session may no longer receive its events.
+func (s *Sessions) deliver(ctx context.Context, sessionID, id string, sub *eventSubscription, e Event) {
+	if !s.allowed(sub.apiCfg, sub.identity) {
+		return
+	}
+	method, params := EventMethod, map[string]any{"subscriptionId": id, "event": e.Payload}
+	if sub.delivery == "resourceUpdated" {
+		var uri string
+		switch {
+		case e.VideoID != "":
+			uri = resources.StatusURI(e.VideoID)
+		case e.LiveStreamID != "":
+			uri = resources.LiveStreamURI(e.LiveStreamID)
+		default:
+			// No resource to point to, e.g. an event type added later
+			return
+		}
+		method, params = mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri}
+	}
+
+	log := slog.With("session_id", sessionID, "subscription_id", id, "event_type", e.Type)
+	if err := s.subs.Send(sessionID, method, params); err != nil {
+		log.DebugContext(ctx, "Forwarding webhook event failed", "error", err)
+		s.mu.Lock()
+		s.dropOrphans()
+		s.mu.Unlock()
+		return
+	}
+	log.DebugContext(ctx, "Webhook event forwarded")
+}
@@ -0,0 +1,179 @@
+package webhooks
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// maxSeen bounds the remembered event IDs. Forgetting an unexpired ID would
+// let its delivery be replayed, so new events are refused instead.
+const maxSeen = 50000
+
+// compactAfter is how many lines the store file holds before it may be
+// rewritten with only the unexpired IDs.
+const compactAfter = 1000
+
+var errStoreFull = errors.New("too many events received within the accepted clock skew")
+
+// store remembers the IDs of accepted events until their emission time is
+// outside the accepted clock skew, after which deliveries of them are refused
+// anyway. With a file set, IDs survive restarts: each accepted ID is appended
+// to the file, which is rewritten once most of its lines have expired.
+type store struct {
+	file string
+
+	mu     sync.Mutex
+	seen   map[string]time.Time // event ID → when it can be forgotten
+	expiry expiryQueue          // the same IDs, soonest to expire first
+	log    *os.File             // the store file, opened for appending
+	lines  int                  // lines in the store file
+}
+
+// entry is an event ID and when it can be forgotten, one per line of the
+// store file.
+type entry struct {
+	ID      string    `json:"id"`
+	Expires time.Time `json:"expires"`
+}
+
+// expiryQueue is a min-heap of entries ordered by expiry.
+type expiryQueue []entry
+
+func (q expiryQueue) Len() int           { return len(q) }
+func (q expiryQueue) Less(i, j int) bool { return q[i].Expires.Before(q[j].Expires) }
+func (q expiryQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *expiryQueue) Push(x any)        { *q = append(*q, x.(entry)) }
+
+func (q *expiryQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+// newStore returns a store kept in file, if not empty, with the unexpired
+// IDs it holds loaded.
+func newStore(file string) *store {
+	s := &store{file: file, seen: map[string]time.Time{}}
+	if file == "" {
+		return s
+	}
+	f, err := os.Open(file)
+	if err == nil {
+		now := time.Now()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			s.lines++
+			var e entry
+			// A line cut short by a crash is skipped, it is rewritten below
+			if json.Unmarshal(scanner.Bytes(), &e) != nil || !now.Before(e.Expires) {
+				continue
+			}
+			if _, ok := s.seen[e.ID]; !ok {
+				s.seen[e.ID] = e.Expires
+				heap.Push(&s.expiry, e)
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+	}
+	if err != nil && !os.IsNotExist(err) {
+		slog.Warn("Failed to load webhook event store", "file", file, "error", err)
+	}
+	if s.lines > len(s.seen) {
+		s.compact()
+	}
+	return s
+}
+
+// add records an event ID until expires and reports whether it was new.
+func (s *store) add(id string, expires time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(time.Now())
+	if _, ok := s.seen[id]; ok {
+		return false, nil
+	}
+	if len(s.seen) >= maxSeen {
+		return false, errStoreFull
+	}
+	e := entry{ID: id, Expires: expires}
+	s.seen[id] = expires
+	heap.Push(&s.expiry, e)
+	s.append(e)
+	return true, nil
+}
+
+// prune forgets expired IDs. s.mu must be held, or s not shared yet.
+func (s *store) prune(now time.Time) {
+	for len(s.expiry) > 0 && !now.Before(s.expiry[0].Expires) {
+		delete(s.seen, heap.Pop(&s.expiry).(entry).ID)
+	}
+}
+
+// append adds e to the store file, or rewrites the file when most of its
+// lines have expired. s.mu must be held.
+func (s *store) append(e entry) {
+	if s.file == "" {
+		return
+	}
+	if s.lines >= compactAfter && s.lines >= 2*len(s.seen) && s.compact() {
+		return
+	}
+	if s.log == nil {
+		f, err := openLog(s.file)
+		if err != nil {
+			slog.Warn("Failed to open webhook event store", "file", s.file, "error", err)
+			return
+		}
+		s.log = f
+	}
+	line, _ := json.Marshal(e)
+	if _, err := s.log.Write(append(line, '\n')); err != nil {
+		slog.Warn("Failed to write webhook event store", "file", s.file, "error", err)
+		return
+	}
+	s.lines++
+}
+
+// compact rewrites the store file with the unexpired IDs and reports whether
+// it succeeded. s.mu must be held, or s not shared yet.
+func (s *store) compact() bool {
+	var data []byte
+	for _, e := range s.expiry {
+		line, _ := json.Marshal(e)
+		data = append(append(data, line...), '\n')
+	}
+	err := os.MkdirAll(filepath.Dir(s.file), 0o700)
+	if err == nil {
+		// Readable only by the server user
+		tmp := s.file + ".tmp"
+		if err = os.WriteFile(tmp, data, 0o600); err == nil {
+			err = os.Rename(tmp, s.file)
+		}
+	}
+	if err != nil {
+		slog.Warn("Failed to rewrite webhook event store", "file", s.file, "error", err)
+		return false
+	}
+	if s.log != nil {
+		s.log.Close()
+		s.log = nil
+	}
+	s.lines = len(s.expiry)
+	return true
+}
+
+func openLog(file string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+}
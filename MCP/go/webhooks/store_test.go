@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreAdd(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		earlier time.Time // expiry of an earlier add of the same ID, zero for none
+		want    bool
+	}{
+		{"new", time.Time{}, true},
+		{"seen, unexpired", now.Add(time.Hour), false},
+		{"seen, expired", now.Add(-time.Second), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStore("")
+			if !tt.earlier.IsZero() {
+				s.add("id", tt.earlier)
+			}
+			if got, err := s.add("id", now.Add(time.Hour)); got != tt.want || err != nil {
+				t.Errorf("add = %v, %v, want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorePrunesInExpiryOrder(t *testing.T) {
+	s := newStore("")
+	now := time.Now()
+	s.add("late", now.Add(time.Hour))
+	s.add("soon", now.Add(time.Millisecond))
+	s.add("later", now.Add(2*time.Hour))
+	s.prune(now.Add(time.Minute))
+	if _, ok := s.seen["soon"]; ok || len(s.seen) != 2 || len(s.expiry) != 2 {
+		t.Errorf("after prune seen = %v, queue holds %d, want late and later", s.seen, len(s.expiry))
+	}
+}
+
+func TestStoreFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events", "seen.jsonl")
+	now := time.Now()
+
+	s := newStore(file)
+	s.add("kept", now.Add(time.Hour))
+	s.add("expired", now.Add(-time.Second))
+	if lines := countLines(t, file); lines != 2 {
+		t.Errorf("store file holds %d lines, want 2 appended", lines)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("store file mode = %v, want 0600", mode)
+	}
+
+	// A restart keeps unexpired IDs and drops the others from the file
+	s = newStore(file)
+	if first, _ := s.add("kept", now.Add(time.Hour)); first {
+		t.Error("replay of an unexpired event accepted after a restart")
+	}
+	if first, _ := s.add("expired", now.Add(time.Hour)); !first {
+		t.Error("event with an expired ID refused after a restart")
+	}
+	if lines := countLines(t, file); lines != 2 {
+		t.Errorf("store file holds %d lines, want 2 after compaction", lines)
+	}
+}
+
+func TestStoreCompacts(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "seen.jsonl")
+	s := newStore(file)
+	now := time.Now()
+	for i := range compactAfter {
+		s.add(strings.Repeat("x", i+1), now.Add(-time.Second))
+	}
+	s.add("live", now.Add(time.Hour))
+	if lines := countLines(t, file); lines != 1 {
+		t.Errorf("store file holds %d lines, want only the unexpired one", lines)
+	}
+}
+
+func countLines(t *testing.T, file string) int {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Count(string(data), "\n")
+}